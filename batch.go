@@ -0,0 +1,209 @@
+package flickr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
+	"gopkg.in/masci/flickr.v3/internal/pool"
+)
+
+// Flickr upload error codes worth a retry: "Service currently unavailable"
+// and "Write operation failed"
+var transientUploadErrors = map[int]bool{
+	105: true,
+	106: true,
+}
+
+// BatchItem is a single file to be uploaded by UploadBatch
+type BatchItem struct {
+	// Path of the file to upload
+	Path string
+	// Upload parameters for this file, nil to use the user's defaults
+	Params *UploadParams
+}
+
+// BatchResult holds the outcome of a single BatchItem upload
+type BatchResult struct {
+	Item BatchItem
+	// ID of the uploaded photo, empty if the upload failed
+	PhotoID string
+	// Number of upload requests performed for this item
+	Attempts int
+	// Whether the file was already on Flickr, see UploadResponse
+	Skipped  bool
+	Replaced bool
+	// Last error occurred, nil on success
+	Err error
+	// Photosets and groups steps, see OrganizePhoto
+	Steps []PostUploadStep
+}
+
+// BatchSummary aggregates the results of a batch upload
+type BatchSummary struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	// Items not uploaded because already on Flickr, not counted as succeeded
+	Skipped int
+	// Items never attempted because the batch was canceled
+	Canceled int
+	Elapsed  time.Duration
+}
+
+// BatchResponse contains a BatchResult for each input item, in the same order
+// items were passed to UploadBatch, and a summary of the whole batch
+type BatchResponse struct {
+	Results []BatchResult
+	Summary BatchSummary
+}
+
+// BatchOptions configures the behaviour of UploadBatch
+type BatchOptions struct {
+	// Number of concurrent uploads
+	Workers int
+	// How many times a failed upload is retried when the error is transient
+	MaxRetries int
+	// Delay before the first retry, doubled at every attempt
+	RetryDelay time.Duration
+	// Minimum interval between two requests across all workers, 0 to disable.
+	// Every request is throttled: uploads, duplicate searches and the
+	// photosets and groups steps.
+	RateLimit time.Duration
+	// HTTP client used for uploads, nil to use the same default as UploadReader
+	HTTPClient *http.Client
+}
+
+// NewBatchOptions provides meaningful default values
+func NewBatchOptions() *BatchOptions {
+	return &BatchOptions{
+		Workers:    4,
+		MaxRetries: 3,
+		RetryDelay: time.Second,
+	}
+}
+
+// Return whether an upload failure is worth a retry
+func isTransientUploadError(resp *UploadResponse, err error) bool {
+	var apiErr *flickErr.Error
+	if errors.As(err, &apiErr) {
+		return resp != nil && transientUploadErrors[resp.ErrorCode()]
+	}
+
+	// errors performing the HTTP request, not the ones opening the file
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// throttledTransport waits for a tick of throttle before sending each request
+type throttledTransport struct {
+	base     http.RoundTripper
+	throttle <-chan time.Time
+}
+
+func (t *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-t.throttle:
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// Return a copy of httpClient whose requests wait for a tick of throttle
+func throttledClient(httpClient *http.Client, throttle <-chan time.Time) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	throttled := *httpClient
+	throttled.Transport = &throttledTransport{base: httpClient.Transport, throttle: throttle}
+	return &throttled
+}
+
+// Upload a single item retrying on transient errors, httpClient is used for
+// the upload requests and client for any other
+func uploadBatchItem(ctx context.Context, client *FlickrClient, item BatchItem, opts *BatchOptions, httpClient *http.Client) BatchResult {
+	result := BatchResult{Item: item}
+	var resp *UploadResponse
+	result.Attempts, result.Err = pool.Retry(ctx, opts.MaxRetries, opts.RetryDelay, func() (bool, error) {
+		var err error
+		resp, err = uploadFileContext(ctx, client, item.Path, item.Params, httpClient)
+		return isTransientUploadError(resp, err), err
+	})
+	if result.Err != nil {
+		return result
+	}
+
+	result.PhotoID = resp.ID
+	result.Skipped = resp.Skipped
+	result.Replaced = resp.Replaced
+	// only brand new photos need to be organized
+	if !resp.Skipped && !resp.Replaced {
+		result.Steps = OrganizePhoto(client, resp.ID, item.Params)
+	}
+	return result
+}
+
+// UploadBatch uploads a set of files using a bounded pool of workers, each
+// working on its own copy of client. Uploads failing with transient errors are
+// retried according to opts, if opts is nil NewBatchOptions defaults are used.
+// Canceling ctx stops the batch: pending items are not uploaded and the
+// in-flight requests are aborted.
+// This call must be signed with write permissions
+func UploadBatch(ctx context.Context, client *FlickrClient, items []BatchItem, opts *BatchOptions) *BatchResponse {
+	if opts == nil {
+		opts = NewBatchOptions()
+	}
+	start := time.Now()
+	results := make([]BatchResult, len(items))
+
+	// all the workers share the same ticker, so that the rate limit applies
+	// to the whole batch
+	var throttle <-chan time.Time
+	uploadClient := opts.HTTPClient
+	if opts.RateLimit > 0 {
+		ticker := time.NewTicker(opts.RateLimit)
+		defer ticker.Stop()
+		throttle = ticker.C
+		if uploadClient == nil {
+			uploadClient = newUploadHTTPClient()
+		}
+		uploadClient = throttledClient(uploadClient, throttle)
+	}
+
+	attempted := pool.Run(ctx, len(items), opts.Workers, func() func(int) {
+		workerClient := client.Clone()
+		if throttle != nil {
+			workerClient.HTTPClient = throttledClient(workerClient.HTTPClient, throttle)
+		}
+		return func(i int) {
+			results[i] = uploadBatchItem(ctx, workerClient, items[i], opts, uploadClient)
+		}
+	})
+
+	summary := BatchSummary{Total: len(items)}
+	for i := range results {
+		switch {
+		case !attempted[i]:
+			results[i] = BatchResult{Item: items[i], Err: ctx.Err()}
+			summary.Canceled++
+		case results[i].Err != nil:
+			summary.Failed++
+		case results[i].Skipped:
+			summary.Skipped++
+		default:
+			summary.Succeeded++
+		}
+	}
+	summary.Elapsed = time.Since(start)
+
+	return &BatchResponse{Results: results, Summary: summary}
+}
@@ -0,0 +1,165 @@
+package flickr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+func tempPhotos(t *testing.T, n int) []BatchItem {
+	items := make([]BatchItem, n)
+	for i := range items {
+		f, err := ioutil.TempFile("", "flickr.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.WriteString("not really a jpeg")
+		f.Close()
+		t.Cleanup(func() { os.Remove(f.Name()) })
+		items[i] = BatchItem{Path: f.Name(), Params: NewUploadParams()}
+	}
+	return items
+}
+
+func TestNewBatchOptions(t *testing.T) {
+	opts := NewBatchOptions()
+	Expect(t, opts.Workers, 4)
+	Expect(t, opts.MaxRetries, 3)
+	Expect(t, opts.RetryDelay, time.Second)
+	Expect(t, opts.RateLimit, time.Duration(0))
+	Expect(t, opts.HTTPClient == nil, true)
+}
+
+func TestUploadBatch(t *testing.T) {
+	fclient := GetTestClient()
+	server, client := FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"><photoid>1234</photoid></rsp>`, "")
+	defer server.Close()
+
+	items := tempPhotos(t, 5)
+	items = append(items, BatchItem{Path: ""})
+	opts := NewBatchOptions()
+	opts.HTTPClient = client
+
+	resp := UploadBatch(context.Background(), fclient, items, opts)
+	Expect(t, len(resp.Results), 6)
+	Expect(t, resp.Summary.Total, 6)
+	Expect(t, resp.Summary.Succeeded, 5)
+	Expect(t, resp.Summary.Failed, 1)
+	Expect(t, resp.Summary.Canceled, 0)
+	for i, res := range resp.Results[:5] {
+		Expect(t, res.Item.Path, items[i].Path)
+		Expect(t, res.PhotoID, "1234")
+		Expect(t, res.Attempts, 1)
+		Expect(t, res.Err, nil)
+	}
+	_, ok := resp.Results[5].Err.(*os.PathError)
+	Expect(t, ok, true)
+	// the caller client must not be touched by workers
+	Expect(t, fclient.Args.Get("title"), "")
+}
+
+func TestUploadBatchRetry(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		if atomic.AddInt32(&hits, 1) < 3 {
+			fmt.Fprintln(w, `<rsp stat="fail"><err code="105" msg="Service currently unavailable" /></rsp>`)
+			return
+		}
+		fmt.Fprintln(w, `<rsp stat="ok"><photoid>42</photoid></rsp>`)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	opts := NewBatchOptions()
+	opts.RetryDelay = time.Millisecond
+	opts.HTTPClient = &http.Client{Transport: RewriteTransport{URL: u}}
+
+	resp := UploadBatch(context.Background(), GetTestClient(), tempPhotos(t, 1), opts)
+	Expect(t, resp.Summary.Succeeded, 1)
+	Expect(t, resp.Results[0].Attempts, 3)
+	Expect(t, resp.Results[0].PhotoID, "42")
+
+	// permanent errors are not retried
+	server2, client := FlickrMock(200, `<rsp stat="fail"><err code="5" msg="Filetype was not recognised" /></rsp>`, "")
+	defer server2.Close()
+	opts.HTTPClient = client
+	resp = UploadBatch(context.Background(), GetTestClient(), tempPhotos(t, 1), opts)
+	Expect(t, resp.Summary.Failed, 1)
+	Expect(t, resp.Results[0].Attempts, 1)
+}
+
+func TestUploadBatchCanceled(t *testing.T) {
+	server, client := FlickrMock(200, `<rsp stat="ok"><photoid>1234</photoid></rsp>`, "")
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opts := NewBatchOptions()
+	opts.HTTPClient = client
+	opts.Workers = 0
+
+	resp := UploadBatch(ctx, GetTestClient(), tempPhotos(t, 3), opts)
+	Expect(t, resp.Summary.Total, 3)
+	Expect(t, resp.Summary.Succeeded, 0)
+	Expect(t, resp.Summary.Failed+resp.Summary.Canceled, 3)
+	for _, res := range resp.Results {
+		Expect(t, res.PhotoID, "")
+		Expect(t, res.Err != nil, true)
+	}
+}
+
+func TestUploadBatchRateLimit(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprintln(w, `<rsp stat="ok"><photoid>1234</photoid></rsp>`)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: RewriteTransport{URL: u}}
+
+	fclient := GetTestClient()
+	fclient.HTTPClient = client
+	items := tempPhotos(t, 2)
+	for _, item := range items {
+		item.Params.PhotosetIds = []string{"111"}
+		item.Params.GroupIds = []string{"1@N01"}
+	}
+	opts := NewBatchOptions()
+	opts.HTTPClient = client
+	opts.RateLimit = 20 * time.Millisecond
+
+	// follow-up steps are throttled along with the uploads
+	resp := UploadBatch(context.Background(), fclient, items, opts)
+	Expect(t, resp.Summary.Succeeded, 2)
+	Expect(t, atomic.LoadInt32(&requests), int32(6))
+	Expect(t, resp.Summary.Elapsed >= 6*opts.RateLimit, true)
+	// the caller's clients are untouched
+	Expect(t, fclient.HTTPClient, client)
+	Expect(t, opts.HTTPClient, client)
+}
+
+func TestIsTransientUploadError(t *testing.T) {
+	_, err := os.Open("")
+	Expect(t, isTransientUploadError(nil, err), false)
+
+	err = &url.Error{Op: "Post", URL: UPLOAD_ENDPOINT, Err: io.ErrUnexpectedEOF}
+	Expect(t, isTransientUploadError(nil, err), true)
+
+	resp := &UploadResponse{}
+	resp.SetErrorCode(106)
+	Expect(t, isTransientUploadError(resp, flickErr.NewError(flickErr.ApiError, "")), true)
+	resp.SetErrorCode(3)
+	Expect(t, isTransientUploadError(resp, flickErr.NewError(flickErr.ApiError, "")), false)
+}
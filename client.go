@@ -106,6 +106,14 @@ func (c *FlickrClient) ClearArgs() {
 	c.Args = url.Values{}
 }
 
+// Clone returns a copy of the client with its own query params. A client is
+// not safe for concurrent use, give each goroutine its own clone.
+func (c *FlickrClient) Clone() *FlickrClient {
+	clone := *c
+	clone.Args = url.Values{}
+	return &clone
+}
+
 // Reset Args and set the default endpoint
 func (c *FlickrClient) Init() {
 	c.ClearArgs()
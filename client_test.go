@@ -40,6 +40,18 @@ func TestClearArgs(t *testing.T) {
 	Expect(t, len(c.Args), 0)
 }
 
+func TestClone(t *testing.T) {
+	c := GetTestClient()
+	c.OAuthToken = "token"
+	c.Args.Set("foo", "bar")
+	clone := c.Clone()
+	Expect(t, clone.OAuthToken, "token")
+	Expect(t, clone.HTTPClient, c.HTTPClient)
+	Expect(t, len(clone.Args), 0)
+	clone.Args.Set("foo", "baz")
+	Expect(t, c.Args.Get("foo"), "bar")
+}
+
 func TestGenerateNonce(t *testing.T) {
 	var nonce string
 	nonce = generateNonce()
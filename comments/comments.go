@@ -0,0 +1,232 @@
+// Package comments wraps the flickr.photos.comments and flickr.photosets.comments
+// API methods
+package comments
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	"gopkg.in/masci/flickr.v3/photos"
+)
+
+type Comment struct {
+	Id         string    `xml:"id,attr"`
+	Author     string    `xml:"author,attr"`
+	AuthorName string    `xml:"authorname,attr"`
+	RealName   string    `xml:"realname,attr"`
+	PathAlias  string    `xml:"path_alias,attr"`
+	IconServer string    `xml:"iconserver,attr"`
+	IconFarm   string    `xml:"iconfarm,attr"`
+	DateCreate time.Time `xml:"-"`
+	Permalink  string    `xml:"permalink,attr"`
+	// Comment text, may contain HTML
+	Content string `xml:",chardata"`
+}
+
+// UnmarshalXML decodes the unix timestamp in datecreate into DateCreate
+func (c *Comment) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type comment Comment
+	raw := struct {
+		*comment
+		DateCreate string `xml:"datecreate,attr"`
+	}{comment: (*comment)(c)}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	c.DateCreate = flickr.ParseUnixTime(raw.DateCreate)
+	return nil
+}
+
+type CommentsResponse struct {
+	flickr.BasicResponse
+	Comments struct {
+		// Only one of the two is set, depending on the method called
+		PhotoId    string    `xml:"photo_id,attr"`
+		PhotosetId string    `xml:"photoset_id,attr"`
+		Items      []Comment `xml:"comment"`
+	} `xml:"comments"`
+}
+
+type AddCommentResponse struct {
+	flickr.BasicResponse
+	Comment struct {
+		Id string `xml:"id,attr"`
+	} `xml:"comment"`
+}
+
+type RecentForContactsResponse struct {
+	flickr.BasicResponse
+	Photos photos.PhotoList `xml:"photos"`
+}
+
+// GetList returns the comments of a photo, minDate and maxDate are optional
+// and restrict the results to comments posted in that range
+func GetList(client *flickr.FlickrClient, photoId string, minDate, maxDate time.Time) (*CommentsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.comments.getList")
+	client.Args.Set("photo_id", photoId)
+	if !minDate.IsZero() {
+		client.Args.Set("min_comment_date", flickr.FormatUnixTime(minDate))
+	}
+	if !maxDate.IsZero() {
+		client.Args.Set("max_comment_date", flickr.FormatUnixTime(maxDate))
+	}
+	client.OAuthSign()
+
+	response := &CommentsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// AddComment adds a comment to a photo.
+// This method requires authentication with 'write' permission.
+func AddComment(client *flickr.FlickrClient, photoId string, text string) (*AddCommentResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.comments.addComment")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("comment_text", text)
+	client.OAuthSign()
+
+	response := &AddCommentResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// EditComment changes the text of a photo comment.
+// This method requires authentication with 'write' permission.
+func EditComment(client *flickr.FlickrClient, commentId string, text string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.comments.editComment")
+	client.Args.Set("comment_id", commentId)
+	client.Args.Set("comment_text", text)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// DeleteComment deletes a photo comment.
+// This method requires authentication with 'write' permission.
+func DeleteComment(client *flickr.FlickrClient, commentId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.comments.deleteComment")
+	client.Args.Set("comment_id", commentId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+type GetRecentForContactsOptionalArgs struct {
+	// Only photos commented after this date, defaults to the last hour
+	DateLastComment time.Time
+	// Restrict results to these contacts NSIDs
+	ContactsFilter []string
+	Extras         flickr.Extras
+	PerPage        int // up to 50
+	Page           int
+}
+
+// GetRecentForContacts returns the calling user's contacts photos that were
+// recently commented.
+// This method requires authentication with 'read' permission.
+func GetRecentForContacts(client *flickr.FlickrClient, opts GetRecentForContactsOptionalArgs) (*RecentForContactsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.comments.getRecentForContacts")
+	if !opts.DateLastComment.IsZero() {
+		client.Args.Set("date_lastcomment", flickr.FormatUnixTime(opts.DateLastComment))
+	}
+	if len(opts.ContactsFilter) > 0 {
+		client.Args.Set("contacts_filter", strings.Join(opts.ContactsFilter, ","))
+	}
+	if len(opts.Extras) > 0 {
+		client.Args.Set("extras", opts.Extras.String())
+	}
+	if opts.PerPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page > 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	client.OAuthSign()
+
+	response := &RecentForContactsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetPhotosetList returns the comments of a photoset
+func GetPhotosetList(client *flickr.FlickrClient, photosetId string) (*CommentsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.comments.getList")
+	client.Args.Set("photoset_id", photosetId)
+	client.OAuthSign()
+
+	response := &CommentsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// AddPhotosetComment adds a comment to a photoset.
+// This method requires authentication with 'write' permission.
+func AddPhotosetComment(client *flickr.FlickrClient, photosetId string, text string) (*AddCommentResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.comments.addComment")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("comment_text", text)
+	client.OAuthSign()
+
+	response := &AddCommentResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// EditPhotosetComment changes the text of a photoset comment.
+// This method requires authentication with 'write' permission.
+func EditPhotosetComment(client *flickr.FlickrClient, commentId string, text string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.comments.editComment")
+	client.Args.Set("comment_id", commentId)
+	client.Args.Set("comment_text", text)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// DeletePhotosetComment deletes a photoset comment.
+// This method requires authentication with 'write' permission.
+func DeletePhotosetComment(client *flickr.FlickrClient, commentId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.comments.deleteComment")
+	client.Args.Set("comment_id", commentId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,130 @@
+package comments
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+const commentsList = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <comments photo_id="109722179">
+    <comment id="6065-109722179-72057594077818641" author="35468159852@N01" authorname="Rev Dan Catt" realname="Daniel Catt" datecreate="1141841470" permalink="http://www.flickr.com/photos/straup/109722179/#comment72057594077818641">Umm, I'm not sure, can I get back to you on that one? &lt;b&gt;yes&lt;/b&gt;</comment>
+    <comment id="6065-109722179-72057594077818642" author="12037949754@N01" authorname="Bees" datecreate="1141841999" permalink="http://www.flickr.com/photos/straup/109722179/#comment72057594077818642">thanks</comment>
+  </comments>
+</rsp>`
+
+const okResponse = `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, commentsList, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "109722179", time.Unix(1141841000, 0), time.Time{})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.getList")
+	flickr.Expect(t, fclient.Args.Get("min_comment_date"), "1141841000")
+	_, ok := fclient.Args["max_comment_date"]
+	flickr.Expect(t, ok, false)
+
+	flickr.Expect(t, resp.Comments.PhotoId, "109722179")
+	flickr.Expect(t, len(resp.Comments.Items), 2)
+	c := resp.Comments.Items[0]
+	flickr.Expect(t, c.Id, "6065-109722179-72057594077818641")
+	flickr.Expect(t, c.Author, "35468159852@N01")
+	flickr.Expect(t, c.RealName, "Daniel Catt")
+	flickr.Expect(t, c.DateCreate.Equal(time.Unix(1141841470, 0)), true)
+	flickr.Expect(t, c.Permalink, "http://www.flickr.com/photos/straup/109722179/#comment72057594077818641")
+	flickr.Expect(t, c.Content, "Umm, I'm not sure, can I get back to you on that one? <b>yes</b>")
+	flickr.Expect(t, resp.Comments.Items[1].DateCreate.Unix(), int64(1141841999))
+
+	// missing dates are left zero
+	c = Comment{}
+	err = xml.Unmarshal([]byte(`<comment id="1">hi</comment>`), &c)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, c.DateCreate.IsZero(), true)
+}
+
+func TestAddComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><comment id="97777-72057594037941949-72057594037942602" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := AddComment(fclient, "123", "nice shot")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.addComment")
+	flickr.Expect(t, fclient.Args.Get("comment_text"), "nice shot")
+	flickr.Expect(t, resp.Comment.Id, "97777-72057594037941949-72057594037942602")
+
+	resp, err = AddPhotosetComment(fclient, "456", "nice set")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.addComment")
+	flickr.Expect(t, fclient.Args.Get("photoset_id"), "456")
+}
+
+func TestEditDeleteComment(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, okResponse, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := EditComment(fclient, "c1", "better text")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.editComment")
+	flickr.Expect(t, fclient.Args.Get("comment_id"), "c1")
+	flickr.Expect(t, fclient.Args.Get("comment_text"), "better text")
+
+	_, err = DeleteComment(fclient, "c1")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.deleteComment")
+
+	_, err = EditPhotosetComment(fclient, "c2", "text")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.editComment")
+
+	_, err = DeletePhotosetComment(fclient, "c2")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.deleteComment")
+	flickr.Expect(t, fclient.Args.Get("comment_id"), "c2")
+}
+
+func TestGetPhotosetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><comments photoset_id="456">
+		<comment id="c2" author="12037949754@N01" authorname="Bees" datecreate="1141841999">great</comment>
+	</comments></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotosetList(fclient, "456")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photosets.comments.getList")
+	flickr.Expect(t, resp.Comments.PhotosetId, "456")
+	flickr.Expect(t, resp.Comments.Items[0].Content, "great")
+}
+
+func TestGetRecentForContacts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><photos page="1" pages="1" perpage="50" total="1">
+		<photo id="123" owner="12037949754@N01" secret="abc" server="1" farm="1" title="Castle" ispublic="1" isfriend="0" isfamily="0" />
+	</photos></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetRecentForContacts(fclient, GetRecentForContactsOptionalArgs{
+		DateLastComment: time.Unix(1141841000, 0),
+		ContactsFilter:  []string{"a@N01", "b@N01"},
+	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.comments.getRecentForContacts")
+	flickr.Expect(t, fclient.Args.Get("date_lastcomment"), "1141841000")
+	flickr.Expect(t, fclient.Args.Get("contacts_filter"), "a@N01,b@N01")
+	_, ok := fclient.Args["page"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, resp.Photos.Photos[0].Title, "Castle")
+}
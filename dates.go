@@ -0,0 +1,88 @@
+package flickr
+
+import (
+	"strconv"
+	"time"
+)
+
+// Flickr sends and expects upload, update and comment dates as unix timestamps
+// while taken dates are MySQL datetimes, with no timezone
+const MySQLDatetimeLayout = "2006-01-02 15:04:05"
+
+// TakenGranularity is how precisely the date a photo was taken is known
+type TakenGranularity int
+
+const (
+	TakenExact TakenGranularity = 0
+	TakenMonth TakenGranularity = 4
+	TakenYear  TakenGranularity = 6
+	TakenCirca TakenGranularity = 8
+)
+
+// TakenDate is when a photo was taken. Flickr stores it without timezone, Time
+// is returned as UTC and only its fields down to Granularity are meaningful.
+type TakenDate struct {
+	Time        time.Time
+	Granularity TakenGranularity
+	// Whether the date couldn't be read from the photo and Flickr used the
+	// upload date in its place
+	Unknown bool
+}
+
+// ParseTakenDate builds a TakenDate from the values of the taken,
+// takengranularity and takenunknown attributes
+func ParseTakenDate(taken, granularity, unknown string) TakenDate {
+	g, _ := strconv.Atoi(granularity)
+	return TakenDate{
+		Time:        ParseMySQLDatetime(taken),
+		Granularity: TakenGranularity(g),
+		Unknown:     unknown == "1",
+	}
+}
+
+func (d TakenDate) IsZero() bool {
+	return d.Time.IsZero()
+}
+
+// String formats the date according to its granularity
+func (d TakenDate) String() string {
+	switch d.Granularity {
+	case TakenMonth:
+		return d.Time.Format("January 2006")
+	case TakenYear:
+		return d.Time.Format("2006")
+	case TakenCirca:
+		return d.Time.Format("circa 2006")
+	default:
+		return d.Time.Format(MySQLDatetimeLayout)
+	}
+}
+
+// FormatUnixTime formats t as a unix timestamp
+func FormatUnixTime(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// ParseUnixTime parses a unix timestamp, returning the zero time on errors
+func ParseUnixTime(value string) time.Time {
+	ts, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0)
+}
+
+// FormatMySQLDatetime formats t as a MySQL datetime, dropping its timezone
+func FormatMySQLDatetime(t time.Time) string {
+	return t.Format(MySQLDatetimeLayout)
+}
+
+// ParseMySQLDatetime parses a MySQL datetime as UTC, returning the zero time
+// on errors
+func ParseMySQLDatetime(value string) time.Time {
+	t, err := time.Parse(MySQLDatetimeLayout, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,40 @@
+package flickr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTakenDate(t *testing.T) {
+	d := ParseTakenDate("2023-04-23 18:54:13", "0", "0")
+	Expect(t, d, TakenDate{Time: time.Date(2023, 4, 23, 18, 54, 13, 0, time.UTC)})
+	Expect(t, d.String(), "2023-04-23 18:54:13")
+
+	d = ParseTakenDate("2023-04-01 00:00:00", "4", "1")
+	Expect(t, d.Granularity, TakenMonth)
+	Expect(t, d.Unknown, true)
+	Expect(t, d.String(), "April 2023")
+
+	d = ParseTakenDate("2023-01-01 00:00:00", "6", "")
+	Expect(t, d.String(), "2023")
+	d = ParseTakenDate("1960-01-01 00:00:00", "8", "")
+	Expect(t, d.String(), "circa 1960")
+
+	d = ParseTakenDate("", "", "")
+	Expect(t, d.IsZero(), true)
+}
+
+func TestUnixTime(t *testing.T) {
+	ts := time.Date(2006, 12, 13, 22, 07, 52, 0, time.UTC)
+	Expect(t, FormatUnixTime(ts), "1166047672")
+	Expect(t, ParseUnixTime("1166047672").Equal(ts), true)
+	Expect(t, ParseUnixTime("2006-12-13").IsZero(), true)
+}
+
+func TestMySQLDatetime(t *testing.T) {
+	// the timezone is dropped
+	ts := time.Date(2006, 12, 13, 22, 07, 52, 0, time.FixedZone("CET", 3600))
+	Expect(t, FormatMySQLDatetime(ts), "2006-12-13 22:07:52")
+	Expect(t, ParseMySQLDatetime("2006-12-13 22:07:52"), time.Date(2006, 12, 13, 22, 07, 52, 0, time.UTC))
+	Expect(t, ParseMySQLDatetime("1166047672").IsZero(), true)
+}
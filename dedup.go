@@ -0,0 +1,131 @@
+package flickr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Namespace and predicate of the machine tag storing the content hash of uploaded files
+const (
+	ChecksumNamespace = "checksum"
+	ChecksumPredicate = "sha256"
+)
+
+// DuplicatePolicy tells what to do when uploading a file whose content hash matches
+// the checksum machine tag of a photo the user already has on Flickr
+type DuplicatePolicy int
+
+const (
+	// Don't check for duplicates nor tag the photo with its checksum
+	UploadDuplicates DuplicatePolicy = iota
+	// Tag the photo with its checksum and skip the upload if already on Flickr
+	SkipDuplicates
+	// Tag the photo with its checksum and replace the existing copy, if any
+	ReplaceDuplicates
+)
+
+// Minimal flickr.photos.search response, only photo IDs are needed
+type checksumSearchResponse struct {
+	BasicResponse
+	Photos struct {
+		Items []struct {
+			Id string `xml:"id,attr"`
+		} `xml:"photo"`
+	} `xml:"photos"`
+}
+
+// ChecksumMachineTag computes the SHA-256 of the contents of r and returns the
+// machine tag used to find duplicates, ex. checksum:sha256=9f86d08...
+func ChecksumMachineTag(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return ChecksumNamespace + ":" + ChecksumPredicate + "=" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FindByMachineTag returns the IDs of the calling user's photos tagged with machineTag.
+// This method requires authentication with 'read' permission.
+func FindByMachineTag(client *FlickrClient, machineTag string) ([]string, error) {
+	response := &checksumSearchResponse{}
+	args := url.Values{
+		"user_id":      {"me"},
+		"machine_tags": {machineTag},
+	}
+	if err := postMethod(client, "flickr.photos.search", args, response); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(response.Photos.Items))
+	for _, p := range response.Photos.Items {
+		ids = append(ids, p.Id)
+	}
+	return ids, nil
+}
+
+// ReplaceReader replaces the file of an existing photo with the contents of photoReader.
+// This call must be signed with write permissions
+func ReplaceReader(client *FlickrClient, photoId string, photoReader io.Reader, name string) (*UploadResponse, error) {
+	return replaceReaderContext(context.Background(), client, photoId, photoReader, name, nil)
+}
+
+// ReplaceFile does same as ReplaceReader but the photo file is passed as a file path
+// This call must be signed with write permissions
+func ReplaceFile(client *FlickrClient, photoId string, path string) (*UploadResponse, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReplaceReader(client, photoId, file, file.Name())
+}
+
+func replaceReaderContext(ctx context.Context, client *FlickrClient, photoId string, photoReader io.Reader, name string, httpClient *http.Client) (*UploadResponse, error) {
+	client.Init()
+	client.EndpointUrl = REPLACE_ENDPOINT
+	client.Args.Set("photo_id", photoId)
+
+	return postFileContext(ctx, client, photoReader, name, httpClient)
+}
+
+// Upload a file tagging it with its checksum, skipping or replacing existing copies
+// according to params.Duplicates
+func uploadDeduplicated(ctx context.Context, client *FlickrClient, file *os.File, params *UploadParams, httpClient *http.Client) (*UploadResponse, error) {
+	tag, err := ChecksumMachineTag(file)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	existing, err := FindByMachineTag(client, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) > 0 {
+		if params.Duplicates == SkipDuplicates {
+			ret := &UploadResponse{ID: existing[0], Skipped: true}
+			ret.SetErrorStatus(false)
+			return ret, nil
+		}
+
+		resp, err := replaceReaderContext(ctx, client, existing[0], file, file.Name(), httpClient)
+		if resp != nil {
+			resp.Replaced = true
+		}
+		return resp, err
+	}
+
+	// don't modify the caller's params, they might be shared among many uploads
+	tagged := *params
+	tagged.Tags = append(append([]string{}, params.Tags...), tag)
+	return uploadReaderContext(ctx, client, file, file.Name(), &tagged, httpClient)
+}
@@ -0,0 +1,101 @@
+package flickr
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+const emptyHash = "checksum:sha256=e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func TestChecksumMachineTag(t *testing.T) {
+	tag, err := ChecksumMachineTag(strings.NewReader(""))
+	Expect(t, err, nil)
+	Expect(t, tag, emptyHash)
+}
+
+func TestFindByMachineTag(t *testing.T) {
+	fclient := GetTestClient()
+	server, client, _ := FlickrMethodsMock(map[string]string{
+		"flickr.photos.search": `<rsp stat="ok"><photos page="1" pages="1" perpage="100" total="2">
+			<photo id="111" owner="me" secret="a" server="1" title="" ispublic="0" isfriend="0" isfamily="0" />
+			<photo id="222" owner="me" secret="b" server="1" title="" ispublic="0" isfriend="0" isfamily="0" />
+		</photos></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	ids, err := FindByMachineTag(fclient, emptyHash)
+	Expect(t, err, nil)
+	Expect(t, len(ids), 2)
+	Expect(t, ids[1], "222")
+	Expect(t, fclient.Args.Get("machine_tags"), emptyHash)
+	Expect(t, fclient.Args.Get("user_id"), "me")
+
+	server, client = FlickrMock(200, `<rsp stat="fail"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err = FindByMachineTag(fclient, emptyHash)
+	Expect(t, err != nil, true)
+}
+
+func TestUploadDeduplicated(t *testing.T) {
+	f, _ := ioutil.TempFile("", "flickr.go")
+	defer os.Remove(f.Name())
+	f.Close()
+
+	found := `<rsp stat="ok"><photos><photo id="111" /></photos></rsp>`
+	notFound := `<rsp stat="ok"><photos></photos></rsp>`
+	// upload and replace requests have no method
+	uploaded := `<rsp stat="ok"><photoid>333</photoid></rsp>`
+
+	fclient := GetTestClient()
+	server, client, called := FlickrMethodsMock(map[string]string{"flickr.photos.search": found, "": uploaded})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := NewUploadParams()
+	params.Tags = []string{"foo"}
+	params.Duplicates = SkipDuplicates
+	resp, err := uploadFileContext(context.Background(), fclient, f.Name(), params, client)
+	Expect(t, err, nil)
+	Expect(t, resp.Skipped, true)
+	Expect(t, resp.HasErrors(), false)
+	Expect(t, resp.ID, "111")
+	Expect(t, len(*called), 1)
+
+	params.Duplicates = ReplaceDuplicates
+	resp, err = uploadFileContext(context.Background(), fclient, f.Name(), params, client)
+	Expect(t, err, nil)
+	Expect(t, resp.Replaced, true)
+	Expect(t, resp.ID, "333")
+	Expect(t, fclient.EndpointUrl, REPLACE_ENDPOINT)
+	Expect(t, fclient.Args.Get("photo_id"), "111")
+
+	server, client, _ = FlickrMethodsMock(map[string]string{"flickr.photos.search": notFound, "": uploaded})
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = uploadFileContext(context.Background(), fclient, f.Name(), params, client)
+	Expect(t, err, nil)
+	Expect(t, resp.Skipped || resp.Replaced, false)
+	Expect(t, resp.ID, "333")
+	Expect(t, fclient.EndpointUrl, UPLOAD_ENDPOINT)
+	Expect(t, fclient.Args.Get("tags"), "foo "+emptyHash)
+	// caller params untouched
+	Expect(t, len(params.Tags), 1)
+
+	// search failures abort the upload
+	server, client, _ = FlickrMethodsMock(map[string]string{"": uploaded})
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err = uploadFileContext(context.Background(), fclient, f.Name(), params, client)
+	Expect(t, err != nil, true)
+}
+
+func TestReplaceFile(t *testing.T) {
+	_, err := ReplaceFile(GetTestClient(), "123", "")
+	_, ok := err.(*os.PathError)
+	Expect(t, ok, true)
+}
@@ -0,0 +1,382 @@
+// Package download fetches photos and videos from Flickr to the local disk
+package download
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+	"gopkg.in/masci/flickr.v3/internal/pool"
+	"gopkg.in/masci/flickr.v3/photos"
+)
+
+const (
+	OriginalLabel      = "Original"
+	VideoOriginalLabel = "Video Original"
+
+	// suffix of incomplete downloads, kept on disk to resume them
+	partialSuffix = ".part"
+
+	// Flickr API error code worth a retry: "Service currently unavailable"
+	serviceUnavailable = 105
+)
+
+// Item is a single photo or video to be downloaded by Download
+type Item struct {
+	PhotoId string
+	// Size label as returned by photos.GetSizes, empty for the original
+	Label string
+	// Destination file, empty to save into Options.Dir
+	Path string
+	// When the photo was taken, used as modification time of the file. If zero
+	// and Options.SetModTime is true, it's read with photos.GetInfo, the file is
+	// downloaded anyway if that fails.
+	DateTaken time.Time
+}
+
+// Result holds the outcome of a single Item download
+type Result struct {
+	Item Item
+	// File written, empty if the size couldn't be resolved
+	Path string
+	// Label of the downloaded size
+	Label string
+	// Final size of the file
+	Size int64
+	// Bytes transferred during this run, less than Size on resumed downloads
+	Written int64
+	// Whether a previous partial download was resumed
+	Resumed bool
+	// Whether the file already existed and was left untouched
+	Skipped bool
+	// Number of download requests performed for this item
+	Attempts int
+	// Last error occurred, nil on success
+	Err error
+}
+
+// Summary aggregates the results of a Download
+type Summary struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	// Items not downloaded because the file already existed
+	Skipped int
+	// Items never attempted because the download was canceled
+	Canceled int
+	// Bytes transferred across all the items
+	Written int64
+	Elapsed time.Duration
+}
+
+// Response contains a Result for each input item, in the same order items
+// were passed to Download, and a summary of the whole download
+type Response struct {
+	Results []Result
+	Summary Summary
+}
+
+// Options configures the behaviour of Download
+type Options struct {
+	// Directory where items without a Path are saved as <photo id>.<ext>
+	Dir string
+	// Number of concurrent downloads
+	Workers int
+	// How many times a failed download or size lookup is retried when the
+	// error is transient, retries resume from the bytes already on disk
+	MaxRetries int
+	// Delay before the first retry, doubled at every attempt
+	RetryDelay time.Duration
+	// Replace files already on disk instead of skipping them
+	Overwrite bool
+	// Set the modification time of the files to the date the photo was taken
+	SetModTime bool
+	// HTTP client used to fetch the files, nil to use http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// NewOptions provides meaningful default values
+func NewOptions() *Options {
+	return &Options{
+		Dir:        ".",
+		Workers:    4,
+		MaxRetries: 3,
+		RetryDelay: time.Second,
+		SetModTime: true,
+	}
+}
+
+func downloadError(format string, a ...interface{}) error {
+	return flickErr.NewError(flickErr.DownloadError, fmt.Sprintf(format, a...))
+}
+
+// ResolveSize picks the size with the given label. An empty label means the
+// original: for videos it's the "Video Original" entry, falling back to the
+// largest available size of the same media when the original can't be
+// downloaded by the calling user.
+func ResolveSize(info *photos.PhotoAccessInfo, label string) (photos.PhotoDownloadInfo, error) {
+	if label != "" {
+		for _, s := range info.Sizes {
+			if s.Label == label {
+				return s, nil
+			}
+		}
+		return photos.PhotoDownloadInfo{}, downloadError("size %q not available", label)
+	}
+
+	isVideo := false
+	for _, s := range info.Sizes {
+		if s.Media == "video" {
+			isVideo = true
+			break
+		}
+	}
+
+	var largest photos.PhotoDownloadInfo
+	largestArea := -1
+	for _, s := range info.Sizes {
+		if (s.Media == "video") != isVideo {
+			continue
+		}
+		if s.Label == OriginalLabel || s.Label == VideoOriginalLabel {
+			return s, nil
+		}
+		// some video sizes have no dimensions, keep them as a last resort
+		w, _ := strconv.Atoi(s.Width)
+		h, _ := strconv.Atoi(s.Height)
+		if w*h > largestArea {
+			largest, largestArea = s, w*h
+		}
+	}
+	if largestArea < 0 {
+		return largest, downloadError("no size available")
+	}
+	return largest, nil
+}
+
+// Build the destination of an item, the extension is taken from the source URL
+func destination(item Item, size photos.PhotoDownloadInfo, dir string) string {
+	if item.Path != "" {
+		return item.Path
+	}
+	ext := ""
+	if u, err := url.Parse(size.Source); err == nil {
+		ext = path.Ext(u.Path)
+	}
+	// video sources are pages like .../play/orig/<secret>/
+	if ext == "" && size.Media == "video" {
+		ext = ".mp4"
+	}
+	return filepath.Join(dir, item.PhotoId+ext)
+}
+
+// Read the taken date of a photo, zero if it can't be read. Taken dates have
+// no timezone, like everywhere else the wall clock time is used as UTC
+func dateTaken(client *flickr.FlickrClient, photoId string) time.Time {
+	info, err := photos.GetInfo(client, photoId, "")
+	if err != nil {
+		return time.Time{}
+	}
+	return info.Photo.Dates.Taken.Time
+}
+
+// Whether a failed API call is worth a retry
+func isTransientAPIError(resp flickr.FlickrResponse, err error) bool {
+	var apiErr *flickErr.Error
+	if errors.As(err, &apiErr) {
+		return resp.ErrorCode() == serviceUnavailable
+	}
+	// errors performing the HTTP request
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// Transfer source into the partial file dest+partialSuffix, resuming from its
+// current length. Return the bytes written, whether the transfer was resumed
+// and whether a failure is worth a retry.
+func fetch(ctx context.Context, httpClient *http.Client, source, dest string) (written int64, resumed bool, transient bool, err error) {
+	partial := dest + partialSuffix
+	var offset int64
+	if fi, err := os.Stat(partial); err == nil {
+		offset = fi.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", source, nil)
+	if err != nil {
+		return 0, false, false, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, false, true, err
+	}
+	defer resp.Body.Close()
+
+	// expected length of the whole file, -1 if unknown
+	total := int64(-1)
+	flags := os.O_WRONLY | os.O_CREATE
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		var start, end int64
+		_, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &total)
+		if err != nil || start != offset {
+			// can't trust the content, start over at the next attempt
+			os.Remove(partial)
+			return 0, false, true, downloadError("unexpected content range %q", resp.Header.Get("Content-Range"))
+		}
+		flags |= os.O_APPEND
+		resumed = true
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// the partial file is stale or already longer than the source
+		os.Remove(partial)
+		return 0, false, true, downloadError("%s returned %s", source, resp.Status)
+	case resp.StatusCode == http.StatusOK:
+		// the server ignored the range, if any
+		flags |= os.O_TRUNC
+		total = resp.ContentLength
+	default:
+		return 0, false, resp.StatusCode >= 500, downloadError("%s returned %s", source, resp.Status)
+	}
+
+	f, err := os.OpenFile(partial, flags, 0644)
+	if err != nil {
+		return 0, false, false, err
+	}
+	written, err = io.Copy(f, resp.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		// keep what we got for the next attempt
+		return written, resumed, true, err
+	}
+
+	fi, err := os.Stat(partial)
+	if err != nil {
+		return written, resumed, false, err
+	}
+	if total >= 0 && fi.Size() != total {
+		if fi.Size() > total {
+			os.Remove(partial)
+		}
+		return written, resumed, true, downloadError("%s: got %d bytes, expected %d", source, fi.Size(), total)
+	}
+	return written, resumed, false, nil
+}
+
+// Download a single item retrying on transient errors
+func downloadItem(ctx context.Context, client *flickr.FlickrClient, item Item, opts *Options) Result {
+	result := Result{Item: item}
+
+	var sizes *photos.PhotoAccessInfo
+	_, err := pool.Retry(ctx, opts.MaxRetries, opts.RetryDelay, func() (bool, error) {
+		var err error
+		sizes, err = photos.GetSizes(client, item.PhotoId)
+		return isTransientAPIError(sizes, err), err
+	})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	size, err := ResolveSize(sizes, item.Label)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Label = size.Label
+	result.Path = destination(item, size, opts.Dir)
+
+	if fi, err := os.Stat(result.Path); err == nil && !opts.Overwrite {
+		result.Skipped = true
+		result.Size = fi.Size()
+		return result
+	}
+
+	taken := item.DateTaken
+	if opts.SetModTime && taken.IsZero() {
+		// only needed for the modification time, download anyway if missing
+		taken = dateTaken(client, item.PhotoId)
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	result.Attempts, result.Err = pool.Retry(ctx, opts.MaxRetries, opts.RetryDelay, func() (bool, error) {
+		written, resumed, transient, err := fetch(ctx, httpClient, size.Source, result.Path)
+		result.Written += written
+		result.Resumed = result.Resumed || resumed
+		return transient, err
+	})
+	if result.Err != nil {
+		return result
+	}
+
+	partial := result.Path + partialSuffix
+	if opts.SetModTime && !taken.IsZero() {
+		if result.Err = os.Chtimes(partial, taken, taken); result.Err != nil {
+			return result
+		}
+	}
+	if result.Err = os.Rename(partial, result.Path); result.Err != nil {
+		return result
+	}
+	if fi, err := os.Stat(result.Path); err == nil {
+		result.Size = fi.Size()
+	}
+	return result
+}
+
+// Download fetches a set of photos and videos using a bounded pool of
+// workers, each working on its own copy of client. Files are written to a
+// partial file renamed once complete, interrupted downloads are resumed at the
+// next attempt or call. If opts is nil NewOptions defaults are used.
+// Canceling ctx stops the download: pending items are not downloaded and the
+// in-flight transfers are aborted.
+// Downloading originals requires authentication with 'read' permission unless
+// the owner allows everybody to download them.
+func Download(ctx context.Context, client *flickr.FlickrClient, items []Item, opts *Options) *Response {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	start := time.Now()
+	results := make([]Result, len(items))
+	attempted := pool.Run(ctx, len(items), opts.Workers, func() func(int) {
+		workerClient := client.Clone()
+		return func(i int) {
+			results[i] = downloadItem(ctx, workerClient, items[i], opts)
+		}
+	})
+
+	summary := Summary{Total: len(items)}
+	for i := range results {
+		switch {
+		case !attempted[i]:
+			results[i] = Result{Item: items[i], Err: ctx.Err()}
+			summary.Canceled++
+		case results[i].Err != nil:
+			summary.Failed++
+		case results[i].Skipped:
+			summary.Skipped++
+		default:
+			summary.Succeeded++
+		}
+		summary.Written += results[i].Written
+	}
+	summary.Elapsed = time.Since(start)
+
+	return &Response{Results: results, Summary: summary}
+}
@@ -0,0 +1,276 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+	"gopkg.in/masci/flickr.v3/photos"
+)
+
+var content = bytes.Repeat([]byte("not really a jpeg "), 100)
+
+// Serve content supporting range requests, record the Range headers received
+func fileServer(t *testing.T) (*httptest.Server, *[]string) {
+	ranges := &[]string{}
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*ranges = append(*ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+	return server, ranges
+}
+
+func apiMock(t *testing.T, source string) *flickr.FlickrClient {
+	fclient := flickr.GetTestClient()
+	server, client, _ := flickr.FlickrMethodsMock(map[string]string{
+		"flickr.photos.getSizes": fmt.Sprintf(`<rsp stat="ok"><sizes>
+			<size label="Medium" width="500" height="375" source="%[1]s/123_abc.jpg" media="photo" />
+			<size label="Original" width="4000" height="3000" source="%[1]s/123_def_o.jpg" media="photo" />
+			</sizes></rsp>`, source),
+		"flickr.photos.getInfo": `<rsp stat="ok"><photo id="123">
+			<dates posted="1672531200" taken="2022-12-24 18:30:00" takengranularity="0" takenunknown="0" />
+			</photo></rsp>`,
+	})
+	t.Cleanup(server.Close)
+	fclient.HTTPClient = client
+	return fclient
+}
+
+func TestNewOptions(t *testing.T) {
+	opts := NewOptions()
+	flickr.Expect(t, opts.Dir, ".")
+	flickr.Expect(t, opts.Workers, 4)
+	flickr.Expect(t, opts.MaxRetries, 3)
+	flickr.Expect(t, opts.SetModTime, true)
+	flickr.Expect(t, opts.HTTPClient == nil, true)
+}
+
+func TestResolveSize(t *testing.T) {
+	photo := &photos.PhotoAccessInfo{Sizes: []photos.PhotoDownloadInfo{
+		{Label: "Medium", Width: "500", Height: "375", Media: "photo"},
+		{Label: "Large", Width: "1024", Height: "768", Media: "photo"},
+	}}
+	size, err := ResolveSize(photo, "")
+	flickr.Expect(t, err, nil)
+	// original not downloadable, fall back to the largest
+	flickr.Expect(t, size.Label, "Large")
+
+	photo.Sizes = append(photo.Sizes, photos.PhotoDownloadInfo{Label: "Original", Width: "4000", Height: "3000", Media: "photo"})
+	size, err = ResolveSize(photo, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, size.Label, "Original")
+
+	size, err = ResolveSize(photo, "Medium")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, size.Label, "Medium")
+
+	_, err = ResolveSize(photo, "Large 2048")
+	ee, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.DownloadError)
+
+	video := &photos.PhotoAccessInfo{Sizes: []photos.PhotoDownloadInfo{
+		{Label: "Original", Width: "1920", Height: "1080", Media: "photo"},
+		{Label: "Site MP4", Width: "640", Height: "360", Media: "video"},
+		{Label: "Video Original", Width: "", Height: "", Media: "video"},
+	}}
+	size, err = ResolveSize(video, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, size.Label, "Video Original")
+
+	video.Sizes = video.Sizes[:2]
+	size, err = ResolveSize(video, "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, size.Label, "Site MP4")
+
+	_, err = ResolveSize(&photos.PhotoAccessInfo{}, "")
+	flickr.Expect(t, err != nil, true)
+}
+
+func TestDownload(t *testing.T) {
+	files, _ := fileServer(t)
+	fclient := apiMock(t, files.URL)
+	opts := NewOptions()
+	opts.Dir = t.TempDir()
+
+	items := []Item{{PhotoId: "123"}, {PhotoId: "456", Label: "Medium"}, {PhotoId: "789", Label: "Thumbnail"}}
+	resp := Download(context.Background(), fclient, items, opts)
+	flickr.Expect(t, resp.Summary.Total, 3)
+	flickr.Expect(t, resp.Summary.Succeeded, 2)
+	flickr.Expect(t, resp.Summary.Failed, 1)
+	flickr.Expect(t, resp.Summary.Written, int64(2*len(content)))
+
+	res := resp.Results[0]
+	flickr.Expect(t, res.Err, nil)
+	flickr.Expect(t, res.Label, "Original")
+	flickr.Expect(t, res.Path, filepath.Join(opts.Dir, "123.jpg"))
+	flickr.Expect(t, res.Size, int64(len(content)))
+	flickr.Expect(t, res.Attempts, 1)
+	data, _ := ioutil.ReadFile(res.Path)
+	flickr.Expect(t, bytes.Equal(data, content), true)
+	fi, _ := os.Stat(res.Path)
+	// no timezone in taken dates, read as UTC
+	flickr.Expect(t, fi.ModTime().Equal(time.Date(2022, 12, 24, 18, 30, 0, 0, time.UTC)), true)
+	_, err := os.Stat(res.Path + ".part")
+	flickr.Expect(t, os.IsNotExist(err), true)
+
+	flickr.Expect(t, resp.Results[1].Label, "Medium")
+	flickr.Expect(t, resp.Results[2].Err != nil, true)
+	// the caller client must not be touched by workers
+	flickr.Expect(t, fclient.Args.Get("method"), "")
+
+	// files already on disk are skipped
+	resp = Download(context.Background(), fclient, items[:1], opts)
+	flickr.Expect(t, resp.Summary.Skipped, 1)
+	flickr.Expect(t, resp.Results[0].Skipped, true)
+	flickr.Expect(t, resp.Results[0].Attempts, 0)
+}
+
+func TestDownloadWithoutDateTaken(t *testing.T) {
+	files, _ := fileServer(t)
+	fclient := flickr.GetTestClient()
+	// getInfo is not mocked and fails
+	server, client, _ := flickr.FlickrMethodsMock(map[string]string{
+		"flickr.photos.getSizes": fmt.Sprintf(`<rsp stat="ok"><sizes>
+			<size label="Original" width="4000" height="3000" source="%s/123_def_o.jpg" media="photo" />
+			</sizes></rsp>`, files.URL),
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+	opts := NewOptions()
+	opts.Dir = t.TempDir()
+
+	resp := Download(context.Background(), fclient, []Item{{PhotoId: "123"}}, opts)
+	res := resp.Results[0]
+	flickr.Expect(t, res.Err, nil)
+	flickr.Expect(t, res.Size, int64(len(content)))
+}
+
+func TestDownloadSizesRetried(t *testing.T) {
+	files, _ := fileServer(t)
+	calls := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.FormValue("photo_id") == "456" {
+			fmt.Fprint(w, `<rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+			return
+		}
+		if calls == 1 {
+			fmt.Fprint(w, `<rsp stat="fail"><err code="105" msg="Service currently unavailable" /></rsp>`)
+			return
+		}
+		fmt.Fprintf(w, `<rsp stat="ok"><sizes>
+			<size label="Original" width="4000" height="3000" source="%s/123_def_o.jpg" media="photo" />
+			</sizes></rsp>`, files.URL)
+	}))
+	defer api.Close()
+	u, _ := url.Parse(api.URL)
+	fclient := flickr.GetTestClient()
+	fclient.HTTPClient = &http.Client{Transport: flickr.RewriteTransport{URL: u}}
+	opts := NewOptions()
+	opts.Dir = t.TempDir()
+	opts.RetryDelay = time.Millisecond
+	opts.SetModTime = false
+
+	resp := Download(context.Background(), fclient, []Item{{PhotoId: "123"}}, opts)
+	res := resp.Results[0]
+	flickr.Expect(t, res.Err, nil)
+	flickr.Expect(t, calls, 2)
+	flickr.Expect(t, res.Attempts, 1)
+
+	// other API errors are not retried
+	calls = 0
+	resp = Download(context.Background(), fclient, []Item{{PhotoId: "456"}}, opts)
+	flickr.Expect(t, resp.Results[0].Err != nil, true)
+	flickr.Expect(t, calls, 1)
+}
+
+func TestDownloadResume(t *testing.T) {
+	files, ranges := fileServer(t)
+	fclient := apiMock(t, files.URL)
+	opts := NewOptions()
+	opts.Dir = t.TempDir()
+	opts.SetModTime = false
+
+	dest := filepath.Join(opts.Dir, "123.jpg")
+	ioutil.WriteFile(dest+".part", content[:500], 0644)
+
+	resp := Download(context.Background(), fclient, []Item{{PhotoId: "123"}}, opts)
+	res := resp.Results[0]
+	flickr.Expect(t, res.Err, nil)
+	flickr.Expect(t, res.Resumed, true)
+	flickr.Expect(t, res.Written, int64(len(content)-500))
+	flickr.Expect(t, res.Size, int64(len(content)))
+	flickr.Expect(t, (*ranges)[0], "bytes=500-")
+	data, _ := ioutil.ReadFile(dest)
+	flickr.Expect(t, bytes.Equal(data, content), true)
+}
+
+func TestDownloadShortBody(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write(content[:10])
+	}))
+	defer files.Close()
+	fclient := apiMock(t, files.URL)
+	opts := NewOptions()
+	opts.Dir = t.TempDir()
+	opts.MaxRetries = 1
+	opts.RetryDelay = time.Millisecond
+
+	resp := Download(context.Background(), fclient, []Item{{PhotoId: "123", DateTaken: time.Now()}}, opts)
+	res := resp.Results[0]
+	flickr.Expect(t, res.Err != nil, true)
+	flickr.Expect(t, res.Attempts, 2)
+	_, err := os.Stat(res.Path)
+	flickr.Expect(t, os.IsNotExist(err), true)
+	// kept to resume later
+	_, err = os.Stat(res.Path + ".part")
+	flickr.Expect(t, err, nil)
+}
+
+func TestDownloadNotFound(t *testing.T) {
+	files := httptest.NewServer(http.NotFoundHandler())
+	defer files.Close()
+	fclient := apiMock(t, files.URL)
+	opts := NewOptions()
+	opts.Dir = t.TempDir()
+
+	resp := Download(context.Background(), fclient, []Item{{PhotoId: "123", DateTaken: time.Now()}}, opts)
+	res := resp.Results[0]
+	ee, ok := res.Err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.DownloadError)
+	// client errors are not retried
+	flickr.Expect(t, res.Attempts, 1)
+}
+
+func TestDownloadCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	files, _ := fileServer(t)
+	fclient := apiMock(t, files.URL)
+	opts := NewOptions()
+	opts.Dir = t.TempDir()
+
+	resp := Download(ctx, fclient, []Item{{PhotoId: "123"}, {PhotoId: "456"}}, opts)
+	flickr.Expect(t, resp.Summary.Canceled+resp.Summary.Failed, 2)
+	for _, res := range resp.Results {
+		flickr.Expect(t, res.Err != nil, true)
+	}
+}
@@ -9,12 +9,18 @@ const (
 	ApiError          = 10
 	RequestTokenError = 20
 	OAuthTokenError   = 30
+	MetadataError     = 40
+	ValidationError   = 50
+	DownloadError     = 60
 )
 
 var errors = map[int]string{
 	ApiError:          "Flickr API returned an error: ",
 	RequestTokenError: "An error occurred during token request: ",
 	OAuthTokenError:   "An error occurred while getting the OAuth token: ",
+	MetadataError:     "Unable to read photo metadata: ",
+	ValidationError:   "Invalid parameters: ",
+	DownloadError:     "Unable to download photo: ",
 }
 
 type Error struct {
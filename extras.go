@@ -0,0 +1,73 @@
+package flickr
+
+import (
+	"strings"
+)
+
+// Extra is an additional field returned for each photo of a list, decoded
+// into the matching fields of Photo
+type Extra string
+
+const (
+	ExtraDescription        Extra = "description"
+	ExtraLicense            Extra = "license"
+	ExtraDateUpload         Extra = "date_upload"
+	ExtraDateTaken          Extra = "date_taken"
+	ExtraOwnerName          Extra = "owner_name"
+	ExtraIconServer         Extra = "icon_server"
+	ExtraOriginalFormat     Extra = "original_format"
+	ExtraLastUpdate         Extra = "last_update"
+	ExtraGeo                Extra = "geo"
+	ExtraTags               Extra = "tags"
+	ExtraMachineTags        Extra = "machine_tags"
+	ExtraOriginalDimensions Extra = "o_dims"
+	ExtraViews              Extra = "views"
+	ExtraMedia              Extra = "media"
+	ExtraPathAlias          Extra = "path_alias"
+	ExtraURLSquare75        Extra = "url_sq"
+	ExtraURLSquare150       Extra = "url_q"
+	ExtraURLThumbnail100    Extra = "url_t"
+	ExtraURLSmall240        Extra = "url_s"
+	ExtraURLSmall320        Extra = "url_n"
+	ExtraURLSmall400        Extra = "url_w"
+	ExtraURLMedium500       Extra = "url_m"
+	ExtraURLMedium640       Extra = "url_z"
+	ExtraURLMedium800       Extra = "url_c"
+	ExtraURLLarge1024       Extra = "url_l"
+	ExtraURLLarge1600       Extra = "url_h"
+	ExtraURLLarge2048       Extra = "url_k"
+	ExtraURLXLarge3K        Extra = "url_3k"
+	ExtraURLXLarge4K        Extra = "url_4k"
+	ExtraURLXLarge4K2to1    Extra = "url_f"
+	ExtraURLXLarge5K        Extra = "url_5k"
+	ExtraURLXLarge6K        Extra = "url_6k"
+	ExtraURLOriginal        Extra = "url_o"
+)
+
+// URLExtra returns the extra requesting the source of a photo in the given
+// size, it's decoded into Photo.URLs
+func URLExtra(size PhotoSize) Extra {
+	for suffix, s := range extraSizes {
+		if s == size {
+			return Extra("url_" + suffix)
+		}
+	}
+	return ""
+}
+
+// Extras is the set of extras requested by list and search methods
+type Extras []Extra
+
+// String returns the extras as a comma separated list, without duplicates
+func (e Extras) String() string {
+	seen := map[Extra]bool{}
+	names := make([]string, 0, len(e))
+	for _, extra := range e {
+		if extra == "" || seen[extra] {
+			continue
+		}
+		seen[extra] = true
+		names = append(names, string(extra))
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,22 @@
+package flickr
+
+import (
+	"testing"
+)
+
+func TestExtras(t *testing.T) {
+	extras := Extras{ExtraTags, ExtraGeo, "", ExtraTags, URLExtra(SizeLarge1600)}
+	Expect(t, extras.String(), "tags,geo,url_h")
+	Expect(t, Extras{}.String(), "")
+	Expect(t, Extras(nil).String(), "")
+}
+
+func TestURLExtra(t *testing.T) {
+	Expect(t, URLExtra(SizeSquare75), ExtraURLSquare75)
+	Expect(t, URLExtra(SizeSmall240), ExtraURLSmall240)
+	Expect(t, URLExtra(SizeMedium500), ExtraURLMedium500)
+	Expect(t, URLExtra(SizeLarge1024), ExtraURLLarge1024)
+	Expect(t, URLExtra(SizeXLarge4K2to1), ExtraURLXLarge4K2to1)
+	Expect(t, URLExtra(SizeOriginal), ExtraURLOriginal)
+	Expect(t, URLExtra("x"), Extra(""))
+}
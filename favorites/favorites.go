@@ -0,0 +1,124 @@
+// Package favorites wraps the flickr.favorites API methods
+package favorites
+
+import (
+	"strconv"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	"gopkg.in/masci/flickr.v3/photos"
+)
+
+type FavoritesResponse struct {
+	flickr.BasicResponse
+	Photos photos.PhotoList `xml:"photos"`
+}
+
+type GetListOptionalArgs struct {
+	// Only photos faved in this range, zero values are ignored
+	MinFaveDate time.Time
+	MaxFaveDate time.Time
+	Extras      flickr.Extras
+	PerPage     int // up to 500, 0 to ignore
+	Page        int // 0 to ignore
+}
+
+// Perform a signed POST request for a method returning a list of favorites
+func getList(client *flickr.FlickrClient, method string, userId string, opts GetListOptionalArgs) (*FavoritesResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", method)
+	if userId != "" {
+		client.Args.Set("user_id", userId)
+	}
+	if !opts.MinFaveDate.IsZero() {
+		client.Args.Set("min_fave_date", flickr.FormatUnixTime(opts.MinFaveDate))
+	}
+	if !opts.MaxFaveDate.IsZero() {
+		client.Args.Set("max_fave_date", flickr.FormatUnixTime(opts.MaxFaveDate))
+	}
+	if len(opts.Extras) > 0 {
+		client.Args.Set("extras", opts.Extras.String())
+	}
+	if opts.PerPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page > 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	client.OAuthSign()
+
+	response := &FavoritesResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Add a photo to the calling user's favorites.
+// This method requires authentication with 'write' permission.
+func Add(client *flickr.FlickrClient, photoId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.favorites.add")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Remove a photo from the calling user's favorites.
+// This method requires authentication with 'write' permission.
+func Remove(client *flickr.FlickrClient, photoId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.favorites.remove")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetList returns the favorite photos of a user, including private ones
+// visible to the calling user. userId is optional and defaults to the
+// calling user.
+// This method requires authentication with 'read' permission.
+func GetList(client *flickr.FlickrClient, userId string, opts GetListOptionalArgs) (*FavoritesResponse, error) {
+	return getList(client, "flickr.favorites.getList", userId, opts)
+}
+
+// GetPublicList returns the public favorite photos of a user
+func GetPublicList(client *flickr.FlickrClient, userId string, opts GetListOptionalArgs) (*FavoritesResponse, error) {
+	return getList(client, "flickr.favorites.getPublicList", userId, opts)
+}
+
+// GetContext returns the photos faved before and after photoId by userId,
+// numPrev and numNext are optional and default to 1.
+func GetContext(client *flickr.FlickrClient, photoId string, userId string,
+	numPrev, numNext int, extras flickr.Extras) (*photos.ContextResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.favorites.getContext")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	if numPrev > 0 {
+		client.Args.Set("num_prev", strconv.Itoa(numPrev))
+	}
+	if numNext > 0 {
+		client.Args.Set("num_next", strconv.Itoa(numNext))
+	}
+	if len(extras) > 0 {
+		client.Args.Set("extras", extras.String())
+	}
+	client.OAuthSign()
+
+	response := &photos.ContextResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,102 @@
+package favorites
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+const favoritesList = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="4" perpage="2" total="7">
+    <photo id="2733" owner="12037949754@N01" secret="123456" server="12" farm="1" title="Orford castle" ispublic="1" isfriend="0" isfamily="0" date_faved="1166047672" ownername="Bees" />
+    <photo id="2734" owner="12037949754@N01" secret="654321" server="12" farm="1" title="Duomo" ispublic="1" isfriend="0" isfamily="0" date_faved="1166047600" ownername="Bees" />
+  </photos>
+</rsp>`
+
+func TestAddRemove(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := Add(fclient, "2733")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.add")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2733")
+
+	_, err = Remove(fclient, "2733")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.remove")
+}
+
+func TestGetList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, favoritesList, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetList(fclient, "", GetListOptionalArgs{
+		MinFaveDate: time.Unix(1166000000, 0),
+		Extras:      flickr.Extras{flickr.ExtraOwnerName, flickr.ExtraDateUpload},
+		PerPage:     2,
+	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.getList")
+	flickr.Expect(t, fclient.Args.Get("min_fave_date"), "1166000000")
+	flickr.Expect(t, fclient.Args.Get("extras"), "owner_name,date_upload")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "2")
+	_, ok := fclient.Args["user_id"]
+	flickr.Expect(t, ok, false)
+	_, ok = fclient.Args["max_fave_date"]
+	flickr.Expect(t, ok, false)
+
+	flickr.Expect(t, resp.Photos.Pages, 4)
+	flickr.Expect(t, resp.Photos.Total, 7)
+	flickr.Expect(t, resp.Photos.Photos[0].DateFaved.Equal(time.Unix(1166047672, 0)), true)
+	flickr.Expect(t, resp.Photos.Photos[1].OwnerName, "Bees")
+
+	resp, err = GetPublicList(fclient, "12037949754@N01", GetListOptionalArgs{MaxFaveDate: time.Unix(1166047700, 0)})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.getPublicList")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "12037949754@N01")
+	flickr.Expect(t, fclient.Args.Get("max_fave_date"), "1166047700")
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+}
+
+func TestGetContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <count>3</count>
+  <prevphoto id="2980" secret="973da1e709" server="2" farm="1" title="boo!" url="/photos/bees/2980/"
+    thumb="https://farm1.staticflickr.com/2/2980_973da1e709_s.jpg" license="4" media="photo" is_faved="1"
+    ownername="Bees" url_q="https://live.staticflickr.com/2/2980_973da1e709_q.jpg" width_q="150" height_q="150" />
+  <nextphoto id="0" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetContext(fclient, "2733", "12037949754@N01", 0, 2,
+		flickr.Extras{flickr.ExtraOwnerName, flickr.ExtraURLSquare150})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.favorites.getContext")
+	flickr.Expect(t, fclient.Args.Get("num_next"), "2")
+	_, ok := fclient.Args["num_prev"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, resp.Count, 3)
+	flickr.Expect(t, len(resp.PrevPhotos()), 1)
+	flickr.Expect(t, resp.PrevPhotos()[0].Title, "boo!")
+	flickr.Expect(t, fclient.Args.Get("extras"), "owner_name,url_q")
+	prev := resp.PrevPhotos()[0]
+	flickr.Expect(t, prev.IsFaved, true)
+	flickr.Expect(t, prev.Url, "/photos/bees/2980/")
+	flickr.Expect(t, prev.Thumb, "https://farm1.staticflickr.com/2/2980_973da1e709_s.jpg")
+	flickr.Expect(t, prev.Farm, 1)
+	flickr.Expect(t, prev.License, 4)
+	flickr.Expect(t, prev.OwnerName, "Bees")
+	flickr.Expect(t, prev.URLs[flickr.SizeSquare150].Width, 150)
+	flickr.Expect(t, len(resp.Next), 1)
+	flickr.Expect(t, len(resp.NextPhotos()), 0)
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"gopkg.in/masci/flickr.v3"
+	"gopkg.in/masci/flickr.v3/photos"
 )
 
 type ThrottleInfo struct {
@@ -114,3 +115,17 @@ func (group *GroupInfoResponse) CanAddPhotos() bool {
 	}
 	return false
 }
+
+// GetPoolContext returns the photos before and after the given one in a group pool
+func GetPoolContext(client *flickr.FlickrClient, groupId, photoId string) (*photos.ContextResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.groups.pools.getContext")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("group_id", groupId)
+	client.OAuthSign()
+	response := &photos.ContextResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -361,3 +361,22 @@ const getGroupsSamplePayload = `<?xml version="1.0" encoding="utf-8" ?>
     <group nsid="888631@N25" id="888631@N25" name="♥♥ People around us ♥♥" member="1" moderator="0" admin="0" privacy="3" photos="960446" iconserver="3062" iconfarm="4" member_count="11546" topic_count="20" pool_count="960446" />
   </groups>
 </rsp>`
+
+func TestGetPoolContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok">
+		<count>300</count>
+		<prevphoto id="2980" secret="973da1e709" server="2" farm="1" title="boo!" url="/photos/bees/2980/in/pool-discuss/" />
+		<nextphoto id="0" />
+	</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPoolContext(fclient, "34427465471@N01", "2983")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.groups.pools.getContext")
+	flickr.Expect(t, fclient.Args.Get("group_id"), "34427465471@N01")
+	flickr.Expect(t, resp.Count, 300)
+	flickr.Expect(t, resp.PrevPhotos()[0].Title, "boo!")
+	flickr.Expect(t, len(resp.NextPhotos()), 0)
+}
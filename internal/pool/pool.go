@@ -0,0 +1,83 @@
+// Package pool implements the worker pool and retry logic shared by the batch
+// uploader and the download manager
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Run calls a work function with each index from 0 to n-1 using a bounded pool
+// of workers, at least one. Every worker calls newWorker once to get its own
+// work function, so that state not safe for concurrent use isn't shared.
+// Canceling ctx stops dispatching indexes, the returned slice tells which ones
+// were handed to a worker.
+func Run(ctx context.Context, n, workers int, newWorker func() func(i int)) []bool {
+	if workers < 1 {
+		workers = 1
+	}
+	attempted := make([]bool, n)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		work := newWorker()
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				attempted[i] = true
+				work(i)
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return attempted
+}
+
+// Retry calls attempt until it succeeds, it fails with an error not worth a
+// retry or maxRetries retries were performed. The first retry is performed
+// after delay, which is doubled at every following one. Canceling ctx stops
+// retrying. Retry returns the number of calls and the last error.
+func Retry(ctx context.Context, maxRetries int, delay time.Duration, attempt func() (transient bool, err error)) (int, error) {
+	attempts := 0
+	for {
+		attempts++
+		transient, err := attempt()
+		if err == nil {
+			return attempts, nil
+		}
+		if ctx.Err() != nil || attempts > maxRetries || !transient {
+			return attempts, err
+		}
+		if !Sleep(ctx, delay) {
+			return attempts, err
+		}
+		delay *= 2
+	}
+}
+
+// Sleep blocks until ctx is done or the given amount of time has passed,
+// it returns false in the former case
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,82 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	"gopkg.in/masci/flickr.v3/internal/pool"
+)
+
+func TestRun(t *testing.T) {
+	var workers, calls int32
+	done := make([]bool, 10)
+	attempted := pool.Run(context.Background(), len(done), 3, func() func(int) {
+		atomic.AddInt32(&workers, 1)
+		return func(i int) {
+			atomic.AddInt32(&calls, 1)
+			done[i] = true
+		}
+	})
+	flickr.Expect(t, workers, int32(3))
+	flickr.Expect(t, calls, int32(10))
+	for i := range done {
+		flickr.Expect(t, done[i], true)
+		flickr.Expect(t, attempted[i], true)
+	}
+
+	// at least one worker
+	attempted = pool.Run(context.Background(), 2, 0, func() func(int) { return func(int) {} })
+	flickr.Expect(t, attempted[1], true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempted = pool.Run(ctx, 5, 1, func() func(int) {
+		return func(int) { t.Error("canceled run dispatched an index") }
+	})
+	flickr.Expect(t, len(attempted), 5)
+	flickr.Expect(t, attempted[0], false)
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	fail := errors.New("fail")
+
+	calls := 0
+	attempts, err := pool.Retry(ctx, 3, time.Millisecond, func() (bool, error) {
+		calls++
+		if calls < 3 {
+			return true, fail
+		}
+		return false, nil
+	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, attempts, 3)
+
+	// transient errors retried at most maxRetries times
+	attempts, err = pool.Retry(ctx, 2, time.Millisecond, func() (bool, error) { return true, fail })
+	flickr.Expect(t, err, fail)
+	flickr.Expect(t, attempts, 3)
+
+	// permanent errors not retried
+	attempts, err = pool.Retry(ctx, 2, time.Millisecond, func() (bool, error) { return false, fail })
+	flickr.Expect(t, err, fail)
+	flickr.Expect(t, attempts, 1)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	attempts, err = pool.Retry(canceled, 2, time.Hour, func() (bool, error) { return true, fail })
+	flickr.Expect(t, err, fail)
+	flickr.Expect(t, attempts, 1)
+}
+
+func TestSleep(t *testing.T) {
+	flickr.Expect(t, pool.Sleep(context.Background(), time.Millisecond), true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	flickr.Expect(t, pool.Sleep(ctx, time.Hour), false)
+}
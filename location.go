@@ -0,0 +1,58 @@
+package flickr
+
+// GeoAccuracy is the precision of a location, from 1 (world level) to 16 (street level)
+type GeoAccuracy int
+
+const (
+	NoAccuracySpecified GeoAccuracy = 0
+	WorldLevel          GeoAccuracy = 1
+	CountryLevel        GeoAccuracy = 3
+	RegionLevel         GeoAccuracy = 6
+	CityLevel           GeoAccuracy = 11
+	StreetLevel         GeoAccuracy = 16
+)
+
+// Valid tells whether the accuracy is a level accepted by Flickr or
+// NoAccuracySpecified
+func (a GeoAccuracy) Valid() bool {
+	return a >= NoAccuracySpecified && a <= StreetLevel
+}
+
+// ValidCoordinates tells whether lat and lon are within the ranges accepted by
+// Flickr, -90 to 90 and -180 to 180
+func ValidCoordinates(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
+
+// GeoContext tells whether a photo was taken indoors or outdoors
+type GeoContext int
+
+const (
+	NoContextSpecified GeoContext = iota
+	Indoors
+	Outdoors
+)
+
+// Place is a node of the places hierarchy, identified either by its
+// Flickr place ID or by its Where On Earth ID
+type Place struct {
+	PlaceId string `xml:"place_id,attr"`
+	WoeId   string `xml:"woeid,attr"`
+	Name    string `xml:",chardata"`
+}
+
+// Location is the geo data attached to a photo, along with the places
+// it was reverse geocoded to
+type Location struct {
+	Latitude      float64     `xml:"latitude,attr"`
+	Longitude     float64     `xml:"longitude,attr"`
+	Accuracy      GeoAccuracy `xml:"accuracy,attr"`
+	Context       GeoContext  `xml:"context,attr"`
+	PlaceId       string      `xml:"place_id,attr"`
+	WoeId         string      `xml:"woeid,attr"`
+	Neighbourhood Place       `xml:"neighbourhood"`
+	Locality      Place       `xml:"locality"`
+	County        Place       `xml:"county"`
+	Region        Place       `xml:"region"`
+	Country       Place       `xml:"country"`
+}
@@ -0,0 +1,20 @@
+package flickr
+
+import (
+	"testing"
+)
+
+func TestGeoAccuracyValid(t *testing.T) {
+	Expect(t, NoAccuracySpecified.Valid(), true)
+	Expect(t, StreetLevel.Valid(), true)
+	Expect(t, GeoAccuracy(-1).Valid(), false)
+	Expect(t, GeoAccuracy(17).Valid(), false)
+}
+
+func TestValidCoordinates(t *testing.T) {
+	Expect(t, ValidCoordinates(0, 0), true)
+	Expect(t, ValidCoordinates(-90, 180), true)
+	Expect(t, ValidCoordinates(90, -180), true)
+	Expect(t, ValidCoordinates(90.1, 0), false)
+	Expect(t, ValidCoordinates(0, -180.1), false)
+}
@@ -0,0 +1,270 @@
+package metadata
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// TIFF tags we are interested in
+const (
+	tagImageDescription  = 0x010e
+	tagXMP               = 0x02bc
+	tagExifIFD           = 0x8769
+	tagGPSIFD            = 0x8825
+	tagDateTimeOriginal  = 0x9003
+	tagDateTimeDigitized = 0x9004
+	tagXPTitle           = 0x9c9b
+	tagXPComment         = 0x9c9c
+	tagXPKeywords        = 0x9c9e
+	tagGPSLatitudeRef    = 0x0001
+	tagGPSLatitude       = 0x0002
+	tagGPSLongitudeRef   = 0x0003
+	tagGPSLongitude      = 0x0004
+)
+
+const (
+	// sanity limits against corrupted files
+	maxIFDEntries   = 1000
+	maxTagValueSize = 1 << 20
+
+	exifDateTimeLayout    = "2006:01:02 15:04:05"
+	exifDateTimeLayoutAlt = "2006-01-02 15:04:05"
+)
+
+// Size in bytes of each TIFF field type, indexed by type
+var tiffTypeSize = [...]uint32{0, 1, 1, 2, 4, 8, 1, 1, 2, 4, 8, 4, 8, 4}
+
+// Values extracted from the EXIF block of a file
+type exifData struct {
+	Title       string
+	Description string
+	Keywords    []string
+	DateTaken   time.Time
+	HasGPS      bool
+	Latitude    float64
+	Longitude   float64
+	// XMP packet embedded in TIFF files
+	XMP []byte
+}
+
+type ifdEntry struct {
+	Type  uint16
+	Count uint32
+	// either the value itself or the offset of the value, depending on its size
+	Value [4]byte
+}
+
+// Read TIFF structures from a stream, offsets are relative to the TIFF header
+type tiffReader struct {
+	r     io.ReaderAt
+	base  int64
+	order binary.ByteOrder
+}
+
+// Read the TIFF header found at base and return the offset of the first IFD
+func newTiffReader(r io.ReaderAt, base int64) (*tiffReader, uint32, error) {
+	var header [8]byte
+	if _, err := r.ReadAt(header[:], base); err != nil {
+		return nil, 0, flickErr.NewError(flickErr.MetadataError, "truncated TIFF header")
+	}
+
+	t := &tiffReader{r: r, base: base}
+	switch string(header[:4]) {
+	case "II*\x00":
+		t.order = binary.LittleEndian
+	case "MM\x00*":
+		t.order = binary.BigEndian
+	default:
+		return nil, 0, flickErr.NewError(flickErr.MetadataError, "invalid TIFF header")
+	}
+
+	return t, t.order.Uint32(header[4:]), nil
+}
+
+// Read the entries of the IFD found at offset
+func (t *tiffReader) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	var buf [12]byte
+	if _, err := t.r.ReadAt(buf[:2], t.base+int64(offset)); err != nil {
+		return nil, flickErr.NewError(flickErr.MetadataError, "truncated IFD")
+	}
+	count := int(t.order.Uint16(buf[:2]))
+	if count > maxIFDEntries {
+		return nil, flickErr.NewError(flickErr.MetadataError, "too many IFD entries")
+	}
+
+	ret := make(map[uint16]ifdEntry, count)
+	for i := 0; i < count; i++ {
+		pos := t.base + int64(offset) + 2 + int64(i)*12
+		if _, err := t.r.ReadAt(buf[:], pos); err != nil {
+			return nil, flickErr.NewError(flickErr.MetadataError, "truncated IFD entry")
+		}
+		e := ifdEntry{
+			Type:  t.order.Uint16(buf[2:]),
+			Count: t.order.Uint32(buf[4:]),
+		}
+		copy(e.Value[:], buf[8:])
+		ret[t.order.Uint16(buf[:2])] = e
+	}
+	return ret, nil
+}
+
+// Return the raw bytes of an entry value, nil if the entry is malformed
+func (t *tiffReader) data(e ifdEntry) []byte {
+	if int(e.Type) >= len(tiffTypeSize) || tiffTypeSize[e.Type] == 0 {
+		return nil
+	}
+	size := uint64(tiffTypeSize[e.Type]) * uint64(e.Count)
+	if size > maxTagValueSize {
+		return nil
+	}
+	if size <= 4 {
+		return e.Value[:size]
+	}
+
+	buf := make([]byte, size)
+	if _, err := t.r.ReadAt(buf, t.base+int64(t.order.Uint32(e.Value[:]))); err != nil {
+		return nil
+	}
+	return buf
+}
+
+// Return the value of an ASCII entry
+func (t *tiffReader) ascii(e ifdEntry) string {
+	b := t.data(e)
+	if i := strings.IndexByte(string(b), 0); i >= 0 {
+		b = b[:i]
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// Return the value of a LONG entry, used for IFD pointers
+func (t *tiffReader) long(e ifdEntry) (uint32, bool) {
+	b := t.data(e)
+	if len(b) != 4 {
+		return 0, false
+	}
+	return t.order.Uint32(b), true
+}
+
+// Return the values of a RATIONAL entry
+func (t *tiffReader) rationals(e ifdEntry) []float64 {
+	b := t.data(e)
+	if e.Type != 5 || len(b) == 0 {
+		return nil
+	}
+	ret := make([]float64, 0, e.Count)
+	for i := 0; i+8 <= len(b); i += 8 {
+		num, den := t.order.Uint32(b[i:]), t.order.Uint32(b[i+4:])
+		if den == 0 {
+			return nil
+		}
+		ret = append(ret, float64(num)/float64(den))
+	}
+	return ret
+}
+
+// Decode the UCS-2 strings Windows stores in XP* tags, always little endian
+func ucs2(b []byte) string {
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := uint16(b[i]) | uint16(b[i+1])<<8
+		if c == 0 {
+			break
+		}
+		u = append(u, c)
+	}
+	return strings.TrimSpace(string(utf16.Decode(u)))
+}
+
+// Convert degrees, minutes and seconds to decimal degrees, negative for S and W
+func gpsDegrees(dms []float64, ref string) (float64, bool) {
+	if len(dms) != 3 {
+		return 0, false
+	}
+	deg := dms[0] + dms[1]/60 + dms[2]/3600
+	if ref == "S" || ref == "W" {
+		deg = -deg
+	}
+	return deg, true
+}
+
+// Parse the date format used by EXIF, keeping the wall clock time in UTC
+func parseExifDate(s string) (time.Time, bool) {
+	for _, layout := range []string{exifDateTimeLayout, exifDateTimeLayoutAlt} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Extract metadata from the TIFF structure starting at base, either a whole
+// TIFF file or the payload of a JPEG APP1 segment or HEIF Exif item
+func readExif(r io.ReaderAt, base int64) (*exifData, error) {
+	t, offset, err := newTiffReader(r, base)
+	if err != nil {
+		return nil, err
+	}
+	ifd0, err := t.readIFD(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &exifData{}
+	if e, ok := ifd0[tagXPTitle]; ok {
+		ret.Title = ucs2(t.data(e))
+	}
+	if e, ok := ifd0[tagImageDescription]; ok {
+		ret.Description = t.ascii(e)
+	}
+	if e, ok := ifd0[tagXPComment]; ok && ret.Description == "" {
+		ret.Description = ucs2(t.data(e))
+	}
+	if e, ok := ifd0[tagXPKeywords]; ok {
+		for _, k := range strings.Split(ucs2(t.data(e)), ";") {
+			if k = strings.TrimSpace(k); k != "" {
+				ret.Keywords = append(ret.Keywords, k)
+			}
+		}
+	}
+	if e, ok := ifd0[tagXMP]; ok {
+		ret.XMP = t.data(e)
+	}
+
+	// sub IFDs are optional, ignore them when broken
+	if e, ok := ifd0[tagExifIFD]; ok {
+		if offset, ok := t.long(e); ok {
+			if exif, err := t.readIFD(offset); err == nil {
+				for _, tag := range []uint16{tagDateTimeOriginal, tagDateTimeDigitized} {
+					if e, ok := exif[tag]; ok {
+						if date, ok := parseExifDate(t.ascii(e)); ok {
+							ret.DateTaken = date
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if e, ok := ifd0[tagGPSIFD]; ok {
+		if offset, ok := t.long(e); ok {
+			if gps, err := t.readIFD(offset); err == nil {
+				lat, okLat := gpsDegrees(t.rationals(gps[tagGPSLatitude]), t.ascii(gps[tagGPSLatitudeRef]))
+				lon, okLon := gpsDegrees(t.rationals(gps[tagGPSLongitude]), t.ascii(gps[tagGPSLongitudeRef]))
+				if okLat && okLon {
+					ret.HasGPS = true
+					ret.Latitude = lat
+					ret.Longitude = lon
+				}
+			}
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,160 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+type testTag struct {
+	Tag   uint16
+	Type  uint16
+	Count uint32
+	Data  []byte
+	// when > 0, the tag is a pointer to the IFD with this index
+	IFD int
+}
+
+func asciiTag(tag uint16, s string) testTag {
+	return testTag{Tag: tag, Type: 2, Count: uint32(len(s) + 1), Data: append([]byte(s), 0)}
+}
+
+func ucs2Tag(tag uint16, s string) testTag {
+	var b []byte
+	for _, c := range utf16.Encode([]rune(s)) {
+		b = append(b, byte(c), byte(c>>8))
+	}
+	b = append(b, 0, 0)
+	return testTag{Tag: tag, Type: 1, Count: uint32(len(b)), Data: b}
+}
+
+func rationalTag(order binary.ByteOrder, tag uint16, values ...uint32) testTag {
+	b := make([]byte, 4*len(values))
+	for i, v := range values {
+		order.PutUint32(b[4*i:], v)
+	}
+	return testTag{Tag: tag, Type: 5, Count: uint32(len(values) / 2), Data: b}
+}
+
+// Build a TIFF structure, the first IFD is IFD0
+func buildTIFF(order binary.ByteOrder, ifds ...[]testTag) []byte {
+	offsets := make([]uint32, len(ifds))
+	pos := uint32(8)
+	for i, ifd := range ifds {
+		offsets[i] = pos
+		pos += 2 + 12*uint32(len(ifd)) + 4
+	}
+
+	buf := &bytes.Buffer{}
+	if order == binary.LittleEndian {
+		buf.WriteString("II*\x00")
+	} else {
+		buf.WriteString("MM\x00*")
+	}
+	binary.Write(buf, order, uint32(8))
+
+	var data []byte
+	for _, ifd := range ifds {
+		binary.Write(buf, order, uint16(len(ifd)))
+		for _, tag := range ifd {
+			binary.Write(buf, order, tag.Tag)
+			var value [4]byte
+			if tag.IFD > 0 {
+				binary.Write(buf, order, uint16(4))
+				binary.Write(buf, order, uint32(1))
+				order.PutUint32(value[:], offsets[tag.IFD])
+			} else {
+				binary.Write(buf, order, tag.Type)
+				binary.Write(buf, order, tag.Count)
+				if len(tag.Data) <= 4 {
+					copy(value[:], tag.Data)
+				} else {
+					order.PutUint32(value[:], pos+uint32(len(data)))
+					data = append(data, tag.Data...)
+				}
+			}
+			buf.Write(value[:])
+		}
+		binary.Write(buf, order, uint32(0))
+	}
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+// A TIFF with description, keywords, date taken and location (41°24'12.2"N 2°10'26.5"W)
+func sampleTIFF(order binary.ByteOrder, extra ...testTag) []byte {
+	ifd0 := append([]testTag{
+		ucs2Tag(tagXPTitle, "Sagrada Família"),
+		asciiTag(tagImageDescription, "  Basilica in Barcelona "),
+		ucs2Tag(tagXPKeywords, "church; gaudí;;barcelona spain"),
+		{Tag: tagExifIFD, IFD: 1},
+		{Tag: tagGPSIFD, IFD: 2},
+	}, extra...)
+	exif := []testTag{
+		asciiTag(tagDateTimeOriginal, "2019:07:14 18:32:05"),
+	}
+	gps := []testTag{
+		asciiTag(tagGPSLatitudeRef, "N"),
+		rationalTag(order, tagGPSLatitude, 41, 1, 24, 1, 122, 10),
+		asciiTag(tagGPSLongitudeRef, "W"),
+		rationalTag(order, tagGPSLongitude, 2, 1, 10, 1, 265, 10),
+	}
+	return buildTIFF(order, ifd0, exif, gps)
+}
+
+func expectFloat(t *testing.T, a, b float64) {
+	if a-b > 1e-6 || b-a > 1e-6 {
+		t.Errorf("Expected %v - Got %v", b, a)
+	}
+}
+
+func TestReadExif(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		exif, err := readExif(bytes.NewReader(sampleTIFF(order)), 0)
+		flickr.Expect(t, err, nil)
+		flickr.Expect(t, exif.Title, "Sagrada Família")
+		flickr.Expect(t, exif.Description, "Basilica in Barcelona")
+		flickr.Expect(t, len(exif.Keywords), 3)
+		flickr.Expect(t, exif.Keywords[1], "gaudí")
+		flickr.Expect(t, exif.Keywords[2], "barcelona spain")
+		flickr.Expect(t, exif.DateTaken, time.Date(2019, 7, 14, 18, 32, 5, 0, time.UTC))
+		flickr.Expect(t, exif.HasGPS, true)
+		expectFloat(t, exif.Latitude, 41+24.0/60+12.2/3600)
+		expectFloat(t, exif.Longitude, -(2 + 10.0/60 + 26.5/3600))
+	}
+}
+
+func TestReadExifKo(t *testing.T) {
+	_, err := readExif(bytes.NewReader([]byte("II*")), 0)
+	flickr.Expect(t, err != nil, true)
+
+	_, err = readExif(bytes.NewReader([]byte("XX*\x00\x08\x00\x00\x00")), 0)
+	flickr.Expect(t, err != nil, true)
+
+	// IFD0 pointing past the end of the file
+	_, err = readExif(bytes.NewReader([]byte("II*\x00\xff\x00\x00\x00")), 0)
+	flickr.Expect(t, err != nil, true)
+
+	// broken sub IFDs are ignored
+	tiff := buildTIFF(binary.LittleEndian, []testTag{
+		asciiTag(tagImageDescription, "foo"),
+		{Tag: tagGPSIFD, Type: 4, Count: 1, Data: []byte{0xff, 0xff, 0, 0}},
+	})
+	exif, err := readExif(bytes.NewReader(tiff), 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, exif.Description, "foo")
+	flickr.Expect(t, exif.HasGPS, false)
+}
+
+func TestParseExifDate(t *testing.T) {
+	d, ok := parseExifDate("2001:02:03 04:05:06")
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, d, time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC))
+
+	_, ok = parseExifDate("0000:00:00 00:00:00")
+	flickr.Expect(t, ok, false)
+}
@@ -0,0 +1,250 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// the meta box only contains item descriptions, actual data lives elsewhere
+const maxMetaBoxSize = 16 << 20
+
+// A box of the ISO base media file format (HEIF/HEIC containers)
+type isoBox struct {
+	Type string
+	// offset of the box payload, header excluded
+	Offset int64
+	Size   int64
+}
+
+// A file region containing the data of a HEIF item
+type heifExtent struct {
+	Offset int64
+	Length int64
+}
+
+// Read the box header found at offset, size is the number of bytes available
+// in the parent container
+func readBoxHeader(r io.ReaderAt, offset, size int64) (*isoBox, error) {
+	var header [16]byte
+	if size < 8 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if _, err := r.ReadAt(header[:8], offset); err != nil {
+		return nil, err
+	}
+
+	box := &isoBox{Type: string(header[4:8]), Offset: offset + 8}
+	boxSize := int64(binary.BigEndian.Uint32(header[:4]))
+	switch boxSize {
+	case 0:
+		// box extends to the end of the container
+		boxSize = size
+	case 1:
+		if _, err := r.ReadAt(header[8:], offset+8); err != nil {
+			return nil, err
+		}
+		boxSize = int64(binary.BigEndian.Uint64(header[8:]))
+		box.Offset += 8
+	}
+	if boxSize < box.Offset-offset || boxSize > size {
+		return nil, io.ErrUnexpectedEOF
+	}
+	box.Size = boxSize - (box.Offset - offset)
+	return box, nil
+}
+
+// Iterate over the boxes contained in the region [offset, offset+size)
+func readBoxes(r io.ReaderAt, offset, size int64, f func(*isoBox) error) error {
+	for end := offset + size; offset < end; {
+		box, err := readBoxHeader(r, offset, end-offset)
+		if err != nil {
+			return err
+		}
+		if err := f(box); err != nil {
+			return err
+		}
+		offset = box.Offset + box.Size
+	}
+	return nil
+}
+
+// A cursor over a byte slice reading big endian integers, reads past the end
+// return zero and set the overflow flag
+type byteCursor struct {
+	b        []byte
+	overflow bool
+}
+
+func (c *byteCursor) next(n int) []byte {
+	if n > len(c.b) {
+		c.overflow = true
+		c.b = nil
+		return make([]byte, n)
+	}
+	ret := c.b[:n]
+	c.b = c.b[n:]
+	return ret
+}
+
+func (c *byteCursor) uint(size int) uint64 {
+	var ret uint64
+	for _, b := range c.next(size) {
+		ret = ret<<8 | uint64(b)
+	}
+	return ret
+}
+
+func (c *byteCursor) cstring() string {
+	i := bytes.IndexByte(c.b, 0)
+	if i < 0 {
+		c.overflow = true
+		return ""
+	}
+	ret := string(c.b[:i])
+	c.b = c.b[i+1:]
+	return ret
+}
+
+// Parse the iinf box and return the ids of the Exif and XMP items
+func parseItemInfo(payload []byte) (exifId, xmpId uint64) {
+	c := &byteCursor{b: payload}
+	version := c.next(4)[0]
+	if version == 0 {
+		c.uint(2)
+	} else {
+		c.uint(4)
+	}
+
+	// infe boxes follow, parse them from the remaining bytes
+	rest := c.b
+	readBoxes(bytes.NewReader(rest), 0, int64(len(rest)), func(box *isoBox) error {
+		if box.Type != "infe" {
+			return nil
+		}
+		e := &byteCursor{b: rest[box.Offset : box.Offset+box.Size]}
+		version := e.next(4)[0]
+		// item types were introduced with version 2
+		if version < 2 {
+			return nil
+		}
+		var id uint64
+		if version == 2 {
+			id = e.uint(2)
+		} else {
+			id = e.uint(4)
+		}
+		e.uint(2) // protection index
+		itemType := string(e.next(4))
+		e.cstring() // item name
+		switch {
+		case itemType == "Exif":
+			exifId = id
+		case itemType == "mime" && e.cstring() == "application/rdf+xml":
+			xmpId = id
+		}
+		return nil
+	})
+	return exifId, xmpId
+}
+
+// Parse the iloc box and return the location of every single-extent item
+// stored in the file itself
+func parseItemLocations(payload []byte) map[uint64]heifExtent {
+	ret := map[uint64]heifExtent{}
+	c := &byteCursor{b: payload}
+	version := c.next(4)[0]
+	sizes := c.next(2)
+	offsetSize, lengthSize := int(sizes[0]>>4), int(sizes[0]&0x0f)
+	baseOffsetSize, indexSize := int(sizes[1]>>4), 0
+	if version == 1 || version == 2 {
+		indexSize = int(sizes[1] & 0x0f)
+	}
+
+	var count uint64
+	if version < 2 {
+		count = c.uint(2)
+	} else {
+		count = c.uint(4)
+	}
+
+	for i := uint64(0); i < count && !c.overflow; i++ {
+		var id uint64
+		if version < 2 {
+			id = c.uint(2)
+		} else {
+			id = c.uint(4)
+		}
+		constructionMethod := uint64(0)
+		if version == 1 || version == 2 {
+			constructionMethod = c.uint(2) & 0x0f
+		}
+		c.uint(2) // data reference index
+		base := c.uint(baseOffsetSize)
+		extents := c.uint(2)
+		var extent heifExtent
+		for j := uint64(0); j < extents; j++ {
+			c.uint(indexSize)
+			extent.Offset = int64(base + c.uint(offsetSize))
+			extent.Length = int64(c.uint(lengthSize))
+		}
+		// items split in many extents or stored in the idat box are not supported
+		if extents == 1 && constructionMethod == 0 && !c.overflow {
+			ret[id] = extent
+		}
+	}
+	return ret
+}
+
+// Locate the Exif and XMP items of a HEIF file, zero-length extents are
+// returned for missing items
+func readHEIF(r io.ReaderAt, size int64) (exif, xmp heifExtent, err error) {
+	var meta *isoBox
+	err = readBoxes(r, 0, size, func(box *isoBox) error {
+		if box.Type == "meta" && meta == nil {
+			meta = box
+		}
+		return nil
+	})
+	if meta == nil {
+		if err == nil {
+			err = flickErr.NewError(flickErr.MetadataError, "HEIF meta box not found")
+		}
+		return exif, xmp, err
+	}
+	if meta.Size > maxMetaBoxSize || meta.Size < 4 {
+		return exif, xmp, flickErr.NewError(flickErr.MetadataError, "invalid HEIF meta box")
+	}
+
+	payload := make([]byte, meta.Size)
+	if _, err = r.ReadAt(payload, meta.Offset); err != nil {
+		return exif, xmp, err
+	}
+
+	// meta is a full box, skip version and flags
+	var exifId, xmpId uint64
+	var locations map[uint64]heifExtent
+	children := payload[4:]
+	err = readBoxes(bytes.NewReader(children), 0, int64(len(children)), func(box *isoBox) error {
+		switch box.Type {
+		case "iinf":
+			exifId, xmpId = parseItemInfo(children[box.Offset : box.Offset+box.Size])
+		case "iloc":
+			locations = parseItemLocations(children[box.Offset : box.Offset+box.Size])
+		}
+		return nil
+	})
+	if err != nil {
+		return exif, xmp, flickErr.NewError(flickErr.MetadataError, "invalid HEIF meta box")
+	}
+
+	if exifId != 0 {
+		exif = locations[exifId]
+	}
+	if xmpId != 0 {
+		xmp = locations[xmpId]
+	}
+	return exif, xmp, nil
+}
@@ -0,0 +1,74 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func box(boxType string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	buf := make([]byte, 8, 8+len(body))
+	binary.BigEndian.PutUint32(buf, uint32(8+len(body)))
+	copy(buf[4:], boxType)
+	return append(buf, body...)
+}
+
+func be(size int, v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	return buf[8-size:]
+}
+
+// Build a HEIF file with an Exif item and an XMP item stored in mdat
+func buildHEIF(tiff []byte, xmp string) []byte {
+	exifItem := append(append(be(4, 6), "Exif\x00\x00"...), tiff...)
+
+	meta := func(mdatOffset uint64) []byte {
+		infeExif := box("infe", be(4, 2<<24), be(2, 1), be(2, 0), []byte("Exif\x00"))
+		infeXMP := box("infe", be(4, 2<<24), be(2, 2), be(2, 0), []byte("mime\x00application/rdf+xml\x00"))
+		infeImage := box("infe", be(4, 2<<24), be(2, 3), be(2, 0), []byte("hvc1\x00"))
+		iinf := box("iinf", be(4, 0), be(2, 3), infeExif, infeXMP, infeImage)
+		iloc := box("iloc", be(4, 0), []byte{0x44, 0x00}, be(2, 2),
+			be(2, 1), be(2, 0), be(2, 1), be(4, mdatOffset), be(4, uint64(len(exifItem))),
+			be(2, 2), be(2, 0), be(2, 1), be(4, mdatOffset+uint64(len(exifItem))), be(4, uint64(len(xmp))))
+		return box("meta", be(4, 0), box("hdlr", make([]byte, 24)), iinf, iloc)
+	}
+
+	ftyp := box("ftyp", []byte("heic\x00\x00\x00\x00mif1heic"))
+	// the size of the meta box doesn't depend on offsets, build it twice
+	offset := uint64(len(ftyp) + len(meta(0)) + 8)
+	return bytes.Join([][]byte{ftyp, meta(offset), box("mdat", exifItem, []byte(xmp))}, nil)
+}
+
+func TestReadHEIF(t *testing.T) {
+	tiff := sampleTIFF(binary.BigEndian)
+	heif := buildHEIF(tiff, sampleXMP)
+
+	exif, xmp, err := readHEIF(bytes.NewReader(heif), int64(len(heif)))
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, exif.Length, int64(10+len(tiff)))
+	flickr.Expect(t, string(heif[exif.Offset+4:exif.Offset+10]), "Exif\x00\x00")
+	flickr.Expect(t, xmp.Length, int64(len(sampleXMP)))
+	flickr.Expect(t, string(heif[xmp.Offset:xmp.Offset+xmp.Length]), sampleXMP)
+}
+
+func TestReadHEIFKo(t *testing.T) {
+	ftyp := box("ftyp", []byte("heic\x00\x00\x00\x00"))
+	_, _, err := readHEIF(bytes.NewReader(ftyp), int64(len(ftyp)))
+	flickr.Expect(t, err != nil, true)
+
+	// box larger than the file
+	broken := append(ftyp, box("meta", make([]byte, 16))[:12]...)
+	_, _, err = readHEIF(bytes.NewReader(broken), int64(len(broken)))
+	flickr.Expect(t, err != nil, true)
+
+	// no Exif nor XMP items
+	empty := append(ftyp, box("meta", be(4, 0), box("iinf", be(4, 0), be(2, 0)))...)
+	exif, xmp, err := readHEIF(bytes.NewReader(empty), int64(len(empty)))
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, exif.Length, int64(0))
+	flickr.Expect(t, xmp.Length, int64(0))
+}
@@ -0,0 +1,259 @@
+// Package metadata reads EXIF and XMP metadata embedded in JPEG, TIFF and HEIF
+// files, so that uploads can keep titles, keywords, dates and locations even when
+// Flickr fails to extract them on its own.
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// Flickr geo accuracy for coordinates coming from a GPS, street level
+const gpsAccuracy = flickr.StreetLevel
+
+// Metadata describes a photo as recorded in its EXIF and XMP blocks, when both
+// are present XMP wins for text fields and EXIF wins for date and location
+type Metadata struct {
+	Title       string
+	Description string
+	Keywords    []string
+	// Date the photo was taken, zero if unknown. Cameras don't record the timezone
+	// so the wall clock time is stored as UTC.
+	DateTaken time.Time
+	// Whether Latitude and Longitude were found
+	HasGPS    bool
+	Latitude  float64
+	Longitude float64
+}
+
+// Merge values from the EXIF and XMP blocks
+func merge(exif *exifData, xmp *xmpData) *Metadata {
+	ret := &Metadata{}
+	if exif != nil {
+		ret.Title = exif.Title
+		ret.Description = exif.Description
+		ret.Keywords = exif.Keywords
+		ret.DateTaken = exif.DateTaken
+		ret.HasGPS = exif.HasGPS
+		ret.Latitude = exif.Latitude
+		ret.Longitude = exif.Longitude
+	}
+	if xmp != nil {
+		if xmp.Title != "" {
+			ret.Title = xmp.Title
+		}
+		if xmp.Description != "" {
+			ret.Description = xmp.Description
+		}
+		if len(xmp.Keywords) > 0 {
+			ret.Keywords = xmp.Keywords
+		}
+		if ret.DateTaken.IsZero() {
+			ret.DateTaken = xmp.DateTaken
+		}
+		if !ret.HasGPS && xmp.HasGPS {
+			ret.HasGPS = true
+			ret.Latitude = xmp.Latitude
+			ret.Longitude = xmp.Longitude
+		}
+	}
+	return ret
+}
+
+// Extract metadata from a JPEG stream, walking the segments until image data begins
+func readJPEG(r io.ReaderAt, size int64) (*Metadata, error) {
+	var exif *exifData
+	var xmp *xmpData
+	exifHeader := []byte("Exif\x00\x00")
+	xmpHeader := []byte("http://ns.adobe.com/xap/1.0/\x00")
+
+	var header [4]byte
+	for pos := int64(2); pos+4 <= size; {
+		if _, err := r.ReadAt(header[:], pos); err != nil {
+			return nil, err
+		}
+		if header[0] != 0xff {
+			return nil, flickErr.NewError(flickErr.MetadataError, "invalid JPEG marker")
+		}
+		marker := header[1]
+		switch {
+		case marker == 0xff:
+			// fill byte
+			pos++
+			continue
+		case marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7):
+			// markers without payload
+			pos += 2
+			continue
+		case marker == 0xda || marker == 0xd9:
+			// start of scan or end of image, no more metadata
+			return merge(exif, xmp), nil
+		}
+
+		length := int64(binary.BigEndian.Uint16(header[2:]))
+		if length < 2 {
+			return nil, flickErr.NewError(flickErr.MetadataError, "invalid JPEG segment")
+		}
+		payload := pos + 4
+		if marker == 0xe1 {
+			prefix := make([]byte, len(xmpHeader))
+			n, _ := r.ReadAt(prefix, payload)
+			prefix = prefix[:n]
+			switch {
+			case exif == nil && bytes.HasPrefix(prefix, exifHeader):
+				exif, _ = readExif(r, payload+int64(len(exifHeader)))
+			case xmp == nil && bytes.HasPrefix(prefix, xmpHeader) && length-2 > int64(len(xmpHeader)):
+				packet := make([]byte, length-2-int64(len(xmpHeader)))
+				if _, err := r.ReadAt(packet, payload+int64(len(xmpHeader))); err == nil {
+					xmp, _ = readXMP(packet)
+				}
+			}
+		}
+		pos += 2 + length
+	}
+
+	return merge(exif, xmp), nil
+}
+
+// Extract metadata from a HEIF/HEIC stream
+func readHEIFMetadata(r io.ReaderAt, size int64) (*Metadata, error) {
+	exifExtent, xmpExtent, err := readHEIF(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var exif *exifData
+	var xmp *xmpData
+	if exifExtent.Length > 4 {
+		// the Exif item starts with the offset of the TIFF header
+		var skip [4]byte
+		if _, err := r.ReadAt(skip[:], exifExtent.Offset); err == nil {
+			exif, _ = readExif(r, exifExtent.Offset+4+int64(binary.BigEndian.Uint32(skip[:])))
+		}
+	}
+	if xmpExtent.Length > 0 && xmpExtent.Length <= maxTagValueSize {
+		packet := make([]byte, xmpExtent.Length)
+		if _, err := r.ReadAt(packet, xmpExtent.Offset); err == nil {
+			xmp, _ = readXMP(packet)
+		}
+	}
+	return merge(exif, xmp), nil
+}
+
+// Read extracts metadata from a JPEG, TIFF or HEIF stream of the given size.
+// Missing or broken metadata blocks are ignored, an error is returned only when
+// the file format is not supported or the container itself is invalid.
+func Read(r io.ReaderAt, size int64) (*Metadata, error) {
+	var header [12]byte
+	n, _ := r.ReadAt(header[:], 0)
+
+	switch {
+	case n >= 2 && header[0] == 0xff && header[1] == 0xd8:
+		return readJPEG(r, size)
+	case n >= 4 && (string(header[:4]) == "II*\x00" || string(header[:4]) == "MM\x00*"):
+		exif, err := readExif(r, 0)
+		if err != nil {
+			return nil, err
+		}
+		var xmp *xmpData
+		if len(exif.XMP) > 0 {
+			xmp, _ = readXMP(exif.XMP)
+		}
+		return merge(exif, xmp), nil
+	case n >= 12 && string(header[4:8]) == "ftyp":
+		return readHEIFMetadata(r, size)
+	}
+
+	return nil, flickErr.NewError(flickErr.MetadataError, "unsupported file format")
+}
+
+// ReadFile extracts metadata from the file at path, see Read
+func ReadFile(path string) (*Metadata, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return Read(file, info.Size())
+}
+
+// Fill sets title, description, tags, date taken and location of params when
+// they are empty
+func (m *Metadata) Fill(params *flickr.UploadParams) {
+	if params.Title == "" {
+		params.Title = m.Title
+	}
+	if params.Description == "" {
+		params.Description = m.Description
+	}
+	if len(params.Tags) == 0 {
+		for _, k := range m.Keywords {
+			// Flickr tags are space separated, multiple words must be quoted
+			if strings.Contains(k, " ") {
+				k = `"` + strings.ReplaceAll(k, `"`, "") + `"`
+			}
+			params.Tags = append(params.Tags, k)
+		}
+	}
+	if params.DateTaken.IsZero() {
+		params.DateTaken = m.DateTaken
+	}
+	if params.Geo == nil && m.HasGPS {
+		params.Geo = &flickr.GeoLocation{
+			Latitude:  m.Latitude,
+			Longitude: m.Longitude,
+			Accuracy:  gpsAccuracy,
+		}
+	}
+}
+
+// UploadParams returns the default upload parameters filled with metadata
+func (m *Metadata) UploadParams() *flickr.UploadParams {
+	params := flickr.NewUploadParams()
+	m.Fill(params)
+	return params
+}
+
+// ApplyToPhoto sets the date taken and the location of an already uploaded photo,
+// useful when the file was uploaded without filling its parameters with Fill.
+// This method requires authentication with 'write' permission.
+func (m *Metadata) ApplyToPhoto(client *flickr.FlickrClient, photoId string) error {
+	params := &flickr.UploadParams{}
+	m.Fill(params)
+	for _, call := range params.FollowUpCalls(photoId) {
+		if err := call.Do(client, &flickr.BasicResponse{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UploadFile uploads the file at path filling params with its embedded metadata,
+// then sets date taken and location on the new photo along with any other
+// follow-up step, see flickr.UploadFileAndOrganize. If params is nil the default
+// upload parameters are used. Files without readable metadata are uploaded anyway.
+// This call must be signed with write permissions
+func UploadFile(client *flickr.FlickrClient, path string, params *flickr.UploadParams) (*flickr.PostUploadResponse, error) {
+	if params == nil {
+		params = flickr.NewUploadParams()
+	}
+	meta, err := ReadFile(path)
+	if err != nil {
+		meta = &Metadata{}
+	}
+	meta.Fill(params)
+
+	return flickr.UploadFileAndOrganize(client, path, params)
+}
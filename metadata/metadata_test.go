@@ -0,0 +1,162 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+func segment(marker byte, payload []byte) []byte {
+	return append([]byte{0xff, marker, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+}
+
+func buildJPEG(tiff []byte, xmp string) []byte {
+	jpeg := []byte{0xff, 0xd8}
+	jpeg = append(jpeg, segment(0xe0, []byte("JFIF\x00\x01\x01"))...)
+	if tiff != nil {
+		jpeg = append(jpeg, segment(0xe1, append([]byte("Exif\x00\x00"), tiff...))...)
+	}
+	if xmp != "" {
+		jpeg = append(jpeg, segment(0xe1, []byte("http://ns.adobe.com/xap/1.0/\x00"+xmp))...)
+	}
+	jpeg = append(jpeg, segment(0xda, []byte{0, 0, 0})...)
+	// image data may contain anything
+	return append(jpeg, "\xff\xe1garbage\xff\xd9"...)
+}
+
+func TestRead(t *testing.T) {
+	tiff := sampleTIFF(binary.LittleEndian)
+	files := map[string][]byte{
+		"jpeg": buildJPEG(tiff, sampleXMP),
+		"tiff": sampleTIFF(binary.BigEndian, testTag{Tag: tagXMP, Type: 7, Count: uint32(len(sampleXMP)), Data: []byte(sampleXMP)}),
+		"heif": buildHEIF(tiff, sampleXMP),
+	}
+
+	for format, file := range files {
+		meta, err := Read(bytes.NewReader(file), int64(len(file)))
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", format, err)
+		}
+		// text fields from XMP
+		flickr.Expect(t, meta.Title, "Duomo")
+		flickr.Expect(t, meta.Description, "The cathedral of Milan")
+		flickr.Expect(t, len(meta.Keywords), 2)
+		// date and location from EXIF
+		flickr.Expect(t, meta.DateTaken, time.Date(2019, 7, 14, 18, 32, 5, 0, time.UTC))
+		flickr.Expect(t, meta.HasGPS, true)
+		expectFloat(t, meta.Latitude, 41+24.0/60+12.2/3600)
+	}
+
+	jpeg := buildJPEG(tiff, "")
+	meta, err := Read(bytes.NewReader(jpeg), int64(len(jpeg)))
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, meta.Title, "Sagrada Família")
+
+	jpeg = buildJPEG(nil, "")
+	meta, err = Read(bytes.NewReader(jpeg), int64(len(jpeg)))
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, meta.Title, "")
+	flickr.Expect(t, meta.DateTaken.IsZero(), true)
+	flickr.Expect(t, meta.HasGPS, false)
+}
+
+func TestReadKo(t *testing.T) {
+	_, err := Read(bytes.NewReader([]byte("GIF89a")), 6)
+	ee, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.MetadataError)
+
+	jpeg := []byte{0xff, 0xd8, 0x00, 0x00, 0x00, 0x00}
+	_, err = Read(bytes.NewReader(jpeg), int64(len(jpeg)))
+	flickr.Expect(t, err != nil, true)
+
+	_, err = ReadFile("")
+	_, ok = err.(*os.PathError)
+	flickr.Expect(t, ok, true)
+}
+
+func TestUploadParams(t *testing.T) {
+	meta := &Metadata{
+		Title:    "Duomo",
+		Keywords: []string{"milano", `gothic "architecture"`},
+	}
+	params := meta.UploadParams()
+	flickr.Expect(t, params.Title, "Duomo")
+	flickr.Expect(t, params.Description, "")
+	flickr.Expect(t, len(params.Tags), 2)
+	flickr.Expect(t, params.Tags[1], `"gothic architecture"`)
+	flickr.Expect(t, params.SafetyLevel, 1)
+	flickr.Expect(t, params.DateTaken.IsZero(), true)
+	flickr.Expect(t, params.Geo == nil, true)
+
+	meta.DateTaken = time.Date(2019, 7, 14, 18, 32, 5, 0, time.UTC)
+	meta.HasGPS = true
+	meta.Latitude = 41.4
+	params = meta.UploadParams()
+	flickr.Expect(t, params.DateTaken, meta.DateTaken)
+	flickr.Expect(t, params.Geo.Latitude, 41.4)
+	flickr.Expect(t, params.Geo.Accuracy, flickr.StreetLevel)
+
+	// values set by the user are preserved
+	params = flickr.NewUploadParams()
+	params.Title = "My title"
+	params.Tags = []string{"mine"}
+	meta.Fill(params)
+	flickr.Expect(t, params.Title, "My title")
+	flickr.Expect(t, len(params.Tags), 1)
+}
+
+func TestApplyToPhoto(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client, called := flickr.FlickrMethodsMock(map[string]string{
+		"flickr.photos.setDates":        `<rsp stat="ok"></rsp>`,
+		"flickr.photos.geo.setLocation": `<rsp stat="ok"></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	err := (&Metadata{}).ApplyToPhoto(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(*called), 0)
+
+	meta := &Metadata{
+		DateTaken: time.Date(2019, 7, 14, 18, 32, 5, 0, time.UTC),
+		HasGPS:    true,
+		Latitude:  41.4,
+		Longitude: -2.17,
+	}
+	err = meta.ApplyToPhoto(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(*called), 2)
+	flickr.Expect(t, (*called)[0], "flickr.photos.geo.setLocation")
+	flickr.Expect(t, (*called)[1], "flickr.photos.setDates")
+	flickr.Expect(t, fclient.Args.Get("date_taken"), "2019-07-14 18:32:05")
+
+	fclient = flickr.GetTestClient()
+	server, client = flickr.FlickrMock(200, `<rsp stat="fail"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	err = meta.ApplyToPhoto(fclient, "123")
+	_, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+}
+
+func TestUploadFile(t *testing.T) {
+	_, err := UploadFile(flickr.GetTestClient(), "", nil)
+	_, ok := err.(*os.PathError)
+	flickr.Expect(t, ok, true)
+
+	f, _ := ioutil.TempFile("", "flickr.go")
+	defer os.Remove(f.Name())
+	f.Write(buildJPEG(sampleTIFF(binary.LittleEndian), ""))
+	f.Close()
+	meta, err := ReadFile(f.Name())
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, meta.Title, "Sagrada Família")
+}
@@ -0,0 +1,167 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// XMP namespaces we are interested in
+const (
+	nsRDF       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	nsDC        = "http://purl.org/dc/elements/1.1/"
+	nsXMP       = "http://ns.adobe.com/xap/1.0/"
+	nsPhotoshop = "http://ns.adobe.com/photoshop/1.0/"
+	nsExif      = "http://ns.adobe.com/exif/1.0/"
+)
+
+// Values extracted from an XMP packet
+type xmpData struct {
+	Title       string
+	Description string
+	Keywords    []string
+	DateTaken   time.Time
+	HasGPS      bool
+	Latitude    float64
+	Longitude   float64
+}
+
+// Layouts of the ISO 8601 subset used by XMP dates
+var xmpDateLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// Parse an XMP date keeping its wall clock time in UTC, to be consistent with EXIF
+func parseXMPDate(s string) (time.Time, bool) {
+	for _, layout := range xmpDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Parse XMP GPS coordinates, in the form "DDD,MM,SSk" or "DDD,MM.mmk"
+// where k is one of N, S, E, W
+func parseXMPCoordinate(s string) (float64, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	ref := strings.ToUpper(s[len(s)-1:])
+	if !strings.Contains("NSEW", ref) {
+		return 0, false
+	}
+
+	dms := []float64{0, 0, 0}
+	parts := strings.Split(s[:len(s)-1], ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return 0, false
+		}
+		dms[i] = v
+	}
+	return gpsDegrees(dms, ref)
+}
+
+// Set a simple XMP property, either found as attribute or element
+func (x *xmpData) setProperty(name xml.Name, value string, lat, lon *string) {
+	switch name {
+	case xml.Name{Space: nsExif, Local: "DateTimeOriginal"},
+		xml.Name{Space: nsPhotoshop, Local: "DateCreated"},
+		xml.Name{Space: nsXMP, Local: "CreateDate"}:
+		// the first one found wins, they usually agree anyway
+		if date, ok := parseXMPDate(value); ok && x.DateTaken.IsZero() {
+			x.DateTaken = date
+		}
+	case xml.Name{Space: nsExif, Local: "GPSLatitude"}:
+		*lat = value
+	case xml.Name{Space: nsExif, Local: "GPSLongitude"}:
+		*lon = value
+	}
+}
+
+// Extract metadata from an XMP packet
+func readXMP(packet []byte) (*xmpData, error) {
+	ret := &xmpData{}
+	var lat, lon string
+	var stack []xml.Name
+
+	decoder := xml.NewDecoder(bytes.NewReader(packet))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, flickErr.NewError(flickErr.MetadataError, "malformed XMP packet")
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name)
+			for _, attr := range t.Attr {
+				ret.setProperty(attr.Name, strings.TrimSpace(attr.Value), &lat, &lon)
+			}
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			value := strings.TrimSpace(string(t))
+			if value == "" || len(stack) == 0 {
+				continue
+			}
+			// values of array properties are wrapped in rdf:Alt, rdf:Bag or rdf:Seq
+			property := stack[len(stack)-1]
+			if property == (xml.Name{Space: nsRDF, Local: "li"}) {
+				if len(stack) < 3 {
+					continue
+				}
+				property = stack[len(stack)-3]
+			}
+
+			switch property {
+			case xml.Name{Space: nsDC, Local: "title"}:
+				if ret.Title == "" {
+					ret.Title = value
+				}
+			case xml.Name{Space: nsDC, Local: "description"}:
+				if ret.Description == "" {
+					ret.Description = value
+				}
+			case xml.Name{Space: nsDC, Local: "subject"}:
+				ret.Keywords = append(ret.Keywords, value)
+			default:
+				ret.setProperty(property, value, &lat, &lon)
+			}
+		}
+	}
+
+	if lat != "" && lon != "" {
+		latitude, okLat := parseXMPCoordinate(lat)
+		longitude, okLon := parseXMPCoordinate(lon)
+		if okLat && okLon {
+			ret.HasGPS = true
+			ret.Latitude = latitude
+			ret.Longitude = longitude
+		}
+	}
+
+	return ret, nil
+}
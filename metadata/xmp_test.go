@@ -0,0 +1,81 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+const sampleXMP = `<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/"
+    xmlns:exif="http://ns.adobe.com/exif/1.0/"
+    photoshop:DateCreated="2018-03-01T10:11:12.50+01:00"
+    exif:GPSLatitude="45,27.6N">
+   <exif:GPSLongitude>9,11,24E</exif:GPSLongitude>
+   <dc:title>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">Duomo</rdf:li>
+    </rdf:Alt>
+   </dc:title>
+   <dc:description>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">The cathedral of Milan</rdf:li>
+     <rdf:li xml:lang="it">Il Duomo di Milano</rdf:li>
+    </rdf:Alt>
+   </dc:description>
+   <dc:subject>
+    <rdf:Bag>
+     <rdf:li>milano</rdf:li>
+     <rdf:li>gothic architecture</rdf:li>
+    </rdf:Bag>
+   </dc:subject>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+func TestReadXMP(t *testing.T) {
+	xmp, err := readXMP([]byte(sampleXMP))
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, xmp.Title, "Duomo")
+	flickr.Expect(t, xmp.Description, "The cathedral of Milan")
+	flickr.Expect(t, len(xmp.Keywords), 2)
+	flickr.Expect(t, xmp.Keywords[1], "gothic architecture")
+	flickr.Expect(t, xmp.DateTaken, time.Date(2018, 3, 1, 10, 11, 12, 500000000, time.UTC))
+	flickr.Expect(t, xmp.HasGPS, true)
+	expectFloat(t, xmp.Latitude, 45.46)
+	expectFloat(t, xmp.Longitude, 9+11.0/60+24.0/3600)
+
+	_, err = readXMP([]byte("<x:xmpmeta><rdf:RDF>"))
+	flickr.Expect(t, err != nil, true)
+}
+
+func TestParseXMPCoordinate(t *testing.T) {
+	v, ok := parseXMPCoordinate("33,52.2S")
+	flickr.Expect(t, ok, true)
+	expectFloat(t, v, -33.87)
+
+	for _, s := range []string{"", "N", "33,52.2", "33S", "33,a,2W", "1,2,3,4E"} {
+		_, ok = parseXMPCoordinate(s)
+		flickr.Expect(t, ok, false)
+	}
+}
+
+func TestParseXMPDate(t *testing.T) {
+	d, ok := parseXMPDate("2010-05")
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, d, time.Date(2010, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	d, ok = parseXMPDate("2010-05-06T07:08:09")
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, d, time.Date(2010, 5, 6, 7, 8, 9, 0, time.UTC))
+
+	_, ok = parseXMPDate("yesterday")
+	flickr.Expect(t, ok, false)
+}
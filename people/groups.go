@@ -0,0 +1,67 @@
+package people
+
+import (
+	"gopkg.in/masci/flickr.v3"
+)
+
+// Group is a group a user belongs to, membership details like Member, Privacy
+// and the counters are only returned by GetGroups
+type Group struct {
+	Nsid           string `xml:"nsid,attr"`
+	Name           string `xml:"name,attr"`
+	Admin          bool   `xml:"admin,attr"`
+	Moderator      bool   `xml:"moderator,attr"`
+	Member         bool   `xml:"member,attr"`
+	EighteenPlus   bool   `xml:"eighteenplus,attr"`
+	InvitationOnly bool   `xml:"invitation_only,attr"`
+	Privacy        int    `xml:"privacy,attr"`
+	IconServer     string `xml:"iconserver,attr"`
+	IconFarm       int    `xml:"iconfarm,attr"`
+	Photos         int    `xml:"photos,attr"`
+	MemberCount    int    `xml:"member_count,attr"`
+	PoolCount      int    `xml:"pool_count,attr"`
+	TopicCount     int    `xml:"topic_count,attr"`
+}
+
+// BuddyIconURL returns the URL of the icon of the group
+func (g *Group) BuddyIconURL() string {
+	return flickr.BuddyIconURL(g.IconFarm, g.IconServer, g.Nsid)
+}
+
+type GroupsResponse struct {
+	flickr.BasicResponse
+	Groups []Group `xml:"groups>group"`
+}
+
+// Returns the public groups a user is a member of, invitation only groups are
+// included only if invitationOnly is true
+func GetPublicGroups(client *flickr.FlickrClient, userId string, invitationOnly bool) (*GroupsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.people.getPublicGroups")
+	client.Args.Set("user_id", userId)
+	if invitationOnly {
+		client.Args.Set("invitation_only", "1")
+	}
+	client.OAuthSign()
+
+	response := &GroupsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Returns all the groups a user is a member of, including private ones.
+// This method requires authentication with 'read' permission.
+func GetGroups(client *flickr.FlickrClient, userId string) (*GroupsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.people.getGroups")
+	client.Args.Set("user_id", userId)
+	client.OAuthSign()
+
+	response := &GroupsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,59 @@
+package people
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func TestGetPublicGroups(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <groups>
+    <group nsid="34427469792@N01" name="FlickrCentral" admin="0" eighteenplus="0" invitation_only="0" />
+    <group nsid="37114057624@N01" name="Cal's Test Group" admin="1" eighteenplus="1" invitation_only="1" />
+  </groups>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPublicGroups(fclient, "12037949754@N01", false)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getPublicGroups")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "12037949754@N01")
+	_, ok := fclient.Args["invitation_only"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, len(resp.Groups), 2)
+	flickr.Expect(t, resp.Groups[0], Group{Nsid: "34427469792@N01", Name: "FlickrCentral"})
+	flickr.Expect(t, resp.Groups[1].Admin, true)
+	flickr.Expect(t, resp.Groups[1].InvitationOnly, true)
+
+	_, err = GetPublicGroups(fclient, "12037949754@N01", true)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("invitation_only"), "1")
+}
+
+func TestGetGroups(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <groups>
+    <group nsid="17274427@N00" name="Cream of the Crop" member="1" moderator="0" admin="0"
+      privacy="3" photos="1463" iconserver="1" iconfarm="1" member_count="2871" pool_count="1463" topic_count="45" />
+  </groups>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetGroups(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getGroups")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "12037949754@N01")
+	group := resp.Groups[0]
+	flickr.Expect(t, group.Member, true)
+	flickr.Expect(t, group.Privacy, 3)
+	flickr.Expect(t, group.MemberCount, 2871)
+	flickr.Expect(t, group.PoolCount, 1463)
+	flickr.Expect(t, group.BuddyIconURL(), "https://farm1.staticflickr.com/1/buddyicons/17274427@N00.jpg")
+}
@@ -2,44 +2,14 @@ package people
 
 import (
 	"strconv"
+	"time"
 
 	"gopkg.in/masci/flickr.v3"
+	"gopkg.in/masci/flickr.v3/photos"
 )
 
-type Photo struct {
-	DateTaken      string `xml:"datetaken,attr"`
-	DateUpload     string `xml:"dateupload,attr"`
-	Description    string `xml:"description"`
-	Geo            string `xml:"geo,attr"`
-	IconServer     string `xml:"iconserver,attr"`
-	Id             string `xml:"id,attr"`
-	IsFamily       bool   `xml:"isfamily,attr"`
-	IsFriend       bool   `xml:"isfriend,attr"`
-	IsPublic       bool   `xml:"ispublic,attr"`
-	LastUpdate     string `xml:"lastupdate,attr"`
-	License        string `xml:"license,attr"`
-	MachineTags    string `xml:"machine_tags,attr"`
-	Media          string `xml:"media,attr"`
-	OriginalFormat string `xml:"originalformat,attr"`
-	Owner          string `xml:"owner,attr"`
-	OwnerName      string `xml:"ownername,attr"`
-	PathAlias      string `xml:"pathalias,attr"`
-	Secret         string `xml:"secret,attr"`
-	Server         string `xml:"server,attr"`
-	Tags           string `xml:"tags,attr"`
-	Title          string `xml:"title,attr"`
-	URLC           string `xml:"url_c,attr"`  // medium 800
-	URLL           string `xml:"url_l,attr"`  // large
-	URLM           string `xml:"url_m,attr"`  // medium 500
-	URLN           string `xml:"url_n,attr"`  // small
-	URLO           string `xml:"url_o,attr"`  // URL of original size image
-	URLQ           string `xml:"url_q,attr"`  // large square
-	URLS           string `xml:"url_s,attr"`  // square
-	URLSQ          string `xml:"url_sq,attr"` // square
-	URLT           string `xml:"url_t,attr"`  // thumbnail
-	URLZ           string `xml:"url_z,attr"`  // medium 640
-	Views          string `xml:"views,attr"`
-}
+// Photo is an item of the photo lists returned by people methods, see flickr.Photo
+type Photo = flickr.Photo
 
 type Photos struct {
 	Page    int     `xml:"page,attr"`
@@ -54,26 +24,28 @@ type GetPhotosResponse struct {
 	Photos Photos `xml:"photos"`
 }
 
-type SafetyLevel int
+// SafetyLevel is the safety level photos are filtered by, see photos.SafetyLevel
+type SafetyLevel = photos.SafetyLevel
 
 const (
-	NoSafetySpecified SafetyLevel = iota
-	Safe
-	Moderate
-	Restricted
+	NoSafetySpecified = photos.NoSafetySpecified
+	Safe              = photos.Safe
+	Moderate          = photos.Moderate
+	Restricted        = photos.Restricted
 )
 
-type ContentType int
+// ContentType is the content type photos are filtered by, see photos.ContentType
+type ContentType = photos.ContentType
 
 const (
-	NoContentTypeSpecified ContentType = iota
-	PhotosOnly
-	ScreenShotsOnly
-	OtherOnly
-	PhotosAndScreenshots
-	ScreenShotsAndOther
-	PhotosAndOther
-	All
+	NoContentTypeSpecified = photos.NoContentTypeSpecified
+	PhotosOnly             = photos.PhotosOnly
+	ScreenShotsOnly        = photos.ScreenShotsOnly
+	OtherOnly              = photos.OtherOnly
+	PhotosAndScreenshots   = photos.PhotosAndScreenshots
+	ScreenShotsAndOther    = photos.ScreenShotsAndOther
+	PhotosAndOther         = photos.PhotosAndOther
+	All                    = photos.AllContentTypes
 )
 
 type PrivacyFilterType int
@@ -89,13 +61,13 @@ const (
 
 type GetPhotosOptionalArgs struct {
 	SafeSearch    SafetyLevel       // optional, set to NoneSpecified to ignore
-	MinUploadDate string            // optional, set to "" to ignore. mysql datetime
-	MaxUploadDate string            // optional, set to "" to ignore. mysql datetime
-	MinTakenDate  string            // optional, set to "" to ignore. mysql datetime
-	MaxTakenDate  string            // optional, set to "" to ignore. mysql datetime
+	MinUploadDate time.Time         // optional, set to zero to ignore
+	MaxUploadDate time.Time         // optional, set to zero to ignore
+	MinTakenDate  time.Time         // optional, set to zero to ignore
+	MaxTakenDate  time.Time         // optional, set to zero to ignore
 	ContentType   ContentType       // optional, set to NoneSpecified to ignore
 	PrivacyFilter PrivacyFilterType // optional, set to NoneSpecified to ignore
-	Extras        string            // optional, set to "" to ignore. comma separated string.
+	Extras        flickr.Extras     // optional, set to nil to ignore
 	PerPage       int               // 0 to ignore
 	Page          int               // 0 to ignore
 }
@@ -109,18 +81,7 @@ func GetPhotos(client *flickr.FlickrClient,
 	if opts.SafeSearch != NoSafetySpecified {
 		client.Args.Set("safe_search", strconv.Itoa(int(opts.SafeSearch)))
 	}
-	if opts.MinUploadDate != "" {
-		client.Args.Set("min_upload_date", opts.MinUploadDate)
-	}
-	if opts.MaxUploadDate != "" {
-		client.Args.Set("min_upload_date", opts.MaxUploadDate)
-	}
-	if opts.MinTakenDate != "" {
-		client.Args.Set("min_taken_date", opts.MinTakenDate)
-	}
-	if opts.MaxTakenDate != "" {
-		client.Args.Set("max_taken_date", opts.MaxTakenDate)
-	}
+	photos.SetDateArgs(client.Args, opts.MinUploadDate, opts.MaxUploadDate, opts.MinTakenDate, opts.MaxTakenDate)
 	if opts.ContentType != NoContentTypeSpecified {
 		client.Args.Set("content_type", strconv.Itoa(int(opts.ContentType)))
 	}
@@ -133,8 +94,8 @@ func GetPhotos(client *flickr.FlickrClient,
 	if opts.Page != 0 {
 		client.Args.Set("page", strconv.Itoa(opts.Page))
 	}
-	if opts.Extras != "" {
-		client.Args.Set("extras", opts.Extras)
+	if len(opts.Extras) > 0 {
+		client.Args.Set("extras", opts.Extras.String())
 	}
 	client.OAuthSign()
 
@@ -147,3 +108,67 @@ func GetPhotos(client *flickr.FlickrClient,
 	//	}
 	return response, err
 }
+
+type GetPhotosOfOptionalArgs struct {
+	OwnerId string        // optional, set to "" to ignore. only photos owned by this user
+	Extras  flickr.Extras // optional, set to nil to ignore
+	PerPage int           // 0 to ignore
+	Page    int           // 0 to ignore
+}
+
+// Returns photos containing a given user, "me" for the calling user
+func GetPhotosOf(client *flickr.FlickrClient,
+	userId string, opts GetPhotosOfOptionalArgs) (*GetPhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.people.getPhotosOf")
+	client.Args.Set("user_id", userId)
+	if opts.OwnerId != "" {
+		client.Args.Set("owner_id", opts.OwnerId)
+	}
+	if opts.PerPage != 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	if len(opts.Extras) > 0 {
+		client.Args.Set("extras", opts.Extras.String())
+	}
+	client.OAuthSign()
+
+	response := &GetPhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
+
+type GetPublicPhotosOptionalArgs struct {
+	SafeSearch SafetyLevel   // optional, set to NoneSpecified to ignore
+	Extras     flickr.Extras // optional, set to nil to ignore
+	PerPage    int           // 0 to ignore
+	Page       int           // 0 to ignore
+}
+
+// Returns the public photos of a user, no authentication is needed
+func GetPublicPhotos(client *flickr.FlickrClient,
+	userId string, opts GetPublicPhotosOptionalArgs) (*GetPhotosResponse, error) {
+	client.Init()
+	client.Args.Set("method", "flickr.people.getPublicPhotos")
+	client.Args.Set("user_id", userId)
+	if opts.SafeSearch != NoSafetySpecified {
+		client.Args.Set("safe_search", strconv.Itoa(int(opts.SafeSearch)))
+	}
+	if opts.PerPage != 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page != 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	if len(opts.Extras) > 0 {
+		client.Args.Set("extras", opts.Extras.String())
+	}
+	client.OAuthSign()
+
+	response := &GetPhotosResponse{}
+	err := flickr.DoGet(client, response)
+	return response, err
+}
@@ -2,6 +2,7 @@ package people
 
 import (
 	"testing"
+	"time"
 
 	"gopkg.in/masci/flickr.v3"
 )
@@ -32,33 +33,39 @@ func TestGetPhotos(t *testing.T) {
 	defer server.Close()
 	fclient.HTTPClient = client
 
-	resp, err := GetPhotos(fclient, "123456@N00", GetPhotosOptionalArgs{})
+	resp, err := GetPhotos(fclient, "123456@N00", GetPhotosOptionalArgs{
+		MinUploadDate: time.Unix(1166047600, 0),
+		MaxUploadDate: time.Unix(1166047672, 0),
+		MaxTakenDate:  time.Date(2006, 12, 13, 22, 0, 0, 0, time.UTC),
+	})
 	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("min_upload_date"), "1166047600")
+	flickr.Expect(t, fclient.Args.Get("max_upload_date"), "1166047672")
+	flickr.Expect(t, fclient.Args.Get("max_taken_date"), "2006-12-13 22:00:00")
+	_, ok := fclient.Args["min_taken_date"]
+	flickr.Expect(t, ok, false)
 	flickr.Expect(t, resp.Photos.Page, 2)
 	flickr.Expect(t, resp.Photos.Pages, 89)
 	flickr.Expect(t, resp.Photos.PerPage, 10)
 	flickr.Expect(t, resp.Photos.Total, 881)
 	flickr.Expect(t, len(resp.Photos.Photos), 4)
-	flickr.Expect(t, resp.Photos.Photos[0], Photo{
-		Id:       "2636",
-		Owner:    "47058503995@N01",
-		Secret:   "a123456",
-		Server:   "2",
-		Title:    "test_04",
-		IsPublic: true,
-		IsFriend: false,
-		IsFamily: false,
-	})
-	flickr.Expect(t, resp.Photos.Photos[3], Photo{
-		Id:       "2610",
-		Owner:    "12037949754@N01",
-		Secret:   "d123456",
-		Server:   "2",
-		Title:    "00_tall",
-		IsPublic: true,
-		IsFriend: false,
-		IsFamily: false,
-	})
+	photo := resp.Photos.Photos[0]
+	flickr.Expect(t, photo.Id, "2636")
+	flickr.Expect(t, photo.Owner, "47058503995@N01")
+	flickr.Expect(t, photo.Secret, "a123456")
+	flickr.Expect(t, photo.Server, "2")
+	flickr.Expect(t, photo.Title, "test_04")
+	flickr.Expect(t, photo.IsPublic, true)
+	flickr.Expect(t, photo.IsFriend, false)
+	flickr.Expect(t, photo.IsFamily, false)
+	flickr.Expect(t, photo.DateUpload.IsZero(), true)
+	flickr.Expect(t, photo.Geo == nil, true)
+	flickr.Expect(t, len(photo.URLs), 0)
+	photo = resp.Photos.Photos[1]
+	flickr.Expect(t, photo.Id, "2635")
+	flickr.Expect(t, photo.IsPublic, false)
+	flickr.Expect(t, photo.IsFriend, true)
+	flickr.Expect(t, photo.IsFamily, true)
 }
 
 func TestGetPhotosExtras(t *testing.T) {
@@ -98,46 +105,114 @@ func TestGetPhotosExtras(t *testing.T) {
 	fclient.HTTPClient = client
 
 	resp, err := GetPhotos(fclient, "123456@N00", GetPhotosOptionalArgs{
-		Extras: "description, license, date_upload, date_taken, owner_name, icon_server, original_format, last_update, geo, tags, machine_tags, views, media, path_alias, url_sq, url_t, url_s, url_q, url_m, url_n, url_z, url_c, url_l, url_o",
+		Extras: flickr.Extras{
+			flickr.ExtraDescription, flickr.ExtraLicense, flickr.ExtraDateUpload, flickr.ExtraDateTaken,
+			flickr.ExtraOwnerName, flickr.ExtraIconServer, flickr.ExtraOriginalFormat, flickr.ExtraLastUpdate,
+			flickr.ExtraGeo, flickr.ExtraTags, flickr.ExtraMachineTags, flickr.ExtraOriginalDimensions,
+			flickr.ExtraViews, flickr.ExtraMedia, flickr.ExtraPathAlias,
+			flickr.ExtraURLSquare75, flickr.ExtraURLThumbnail100, flickr.ExtraURLSmall240, flickr.ExtraURLSquare150,
+			flickr.ExtraURLMedium500, flickr.ExtraURLSmall320, flickr.ExtraURLMedium640, flickr.ExtraURLMedium800,
+			flickr.ExtraURLLarge1024, flickr.ExtraURLOriginal,
+		},
 	})
 	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("extras"), "description,license,date_upload,date_taken,owner_name,"+
+		"icon_server,original_format,last_update,geo,tags,machine_tags,o_dims,views,media,path_alias,"+
+		"url_sq,url_t,url_s,url_q,url_m,url_n,url_z,url_c,url_l,url_o")
 	flickr.Expect(t, resp.Photos.Page, 1)
 	flickr.Expect(t, resp.Photos.Pages, 5923)
 	flickr.Expect(t, resp.Photos.PerPage, 1)
 	flickr.Expect(t, resp.Photos.Total, 11845)
 	flickr.Expect(t, len(resp.Photos.Photos), 1)
-	flickr.Expect(t, resp.Photos.Photos[0], Photo{
-		DateTaken:      "2023-04-23 18:54:13",
-		DateUpload:     "1682290477",
-		Description:    "New skeletyl keyboard",
-		Geo:            "unittestgeo",
-		IconServer:     "65535",
-		Id:             "52840975319",
-		IsFamily:       false,
-		IsFriend:       false,
-		IsPublic:       true,
-		LastUpdate:     "1682290500",
-		License:        "0",
-		MachineTags:    "unittestmachinetags",
-		Media:          "photo",
-		OriginalFormat: "jpg",
-		Owner:          "123456@N00",
-		OwnerName:      "Testy McTestFace",
-		PathAlias:      "somealias",
-		Secret:         "redacted1",
-		Server:         "65535",
-		Tags:           "unittesttags",
-		Title:          "",
-		URLC:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0_c.jpg",
-		URLL:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0_b.jpg",
-		URLM:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0.jpg",
-		URLN:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0_n.jpg",
-		URLO:           "https://live.staticflickr.com/65535/52840975319_02c20aba2d_o.jpg",
-		URLQ:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0_q.jpg",
-		URLS:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0_m.jpg",
-		URLSQ:          "https://live.staticflickr.com/65535/52840975319_16de78c2d0_s.jpg",
-		URLT:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0_t.jpg",
-		URLZ:           "https://live.staticflickr.com/65535/52840975319_16de78c2d0_z.jpg",
-		Views:          "0",
+	photo := resp.Photos.Photos[0]
+	flickr.Expect(t, photo.Id, "52840975319")
+	flickr.Expect(t, photo.Owner, "123456@N00")
+	flickr.Expect(t, photo.Secret, "redacted1")
+	flickr.Expect(t, photo.Server, "65535")
+	flickr.Expect(t, photo.Farm, 66)
+	flickr.Expect(t, photo.Title, "")
+	flickr.Expect(t, photo.IsPublic, true)
+	flickr.Expect(t, photo.Description, "New skeletyl keyboard")
+	flickr.Expect(t, photo.License, 0)
+	flickr.Expect(t, photo.DateUpload.Equal(time.Unix(1682290477, 0)), true)
+	flickr.Expect(t, photo.LastUpdate.Equal(time.Unix(1682290500, 0)), true)
+	flickr.Expect(t, photo.DateTaken, flickr.TakenDate{Time: time.Date(2023, 4, 23, 18, 54, 13, 0, time.UTC)})
+	flickr.Expect(t, photo.OwnerName, "Testy McTestFace")
+	flickr.Expect(t, photo.IconServer, "65535")
+	flickr.Expect(t, photo.IconFarm, 66)
+	flickr.Expect(t, photo.OriginalSecret, "redactedoriginalsecret")
+	flickr.Expect(t, photo.OriginalFormat, "jpg")
+	flickr.Expect(t, photo.OriginalWidth, 4032)
+	flickr.Expect(t, photo.OriginalHeight, 3024)
+	flickr.Expect(t, photo.Views, 0)
+	flickr.Expect(t, photo.Media, "photo")
+	flickr.Expect(t, photo.PathAlias, "somealias")
+	// latitude and longitude are 0 for photos without location
+	flickr.Expect(t, photo.Geo == nil, true)
+	flickr.Expect(t, len(photo.Tags), 1)
+	flickr.Expect(t, photo.Tags[0], "unittesttags")
+	// not in the namespace:predicate=value form
+	flickr.Expect(t, len(photo.MachineTags), 0)
+	flickr.Expect(t, len(photo.URLs), 10)
+	flickr.Expect(t, photo.URLs[flickr.SizeSquare75], flickr.ImageURL{
+		URL: "https://live.staticflickr.com/65535/52840975319_16de78c2d0_s.jpg", Width: 75, Height: 75})
+	flickr.Expect(t, photo.URLs[flickr.SizeSmall240], flickr.ImageURL{
+		URL: "https://live.staticflickr.com/65535/52840975319_16de78c2d0_m.jpg", Width: 240, Height: 180})
+	flickr.Expect(t, photo.URLs[flickr.SizeMedium500], flickr.ImageURL{
+		URL: "https://live.staticflickr.com/65535/52840975319_16de78c2d0.jpg", Width: 500, Height: 375})
+	flickr.Expect(t, photo.URLs[flickr.SizeLarge1024], flickr.ImageURL{
+		URL: "https://live.staticflickr.com/65535/52840975319_16de78c2d0_b.jpg", Width: 1024, Height: 768})
+	flickr.Expect(t, photo.URLs[flickr.SizeOriginal], flickr.ImageURL{
+		URL: "https://live.staticflickr.com/65535/52840975319_02c20aba2d_o.jpg", Width: 4032, Height: 3024})
+}
+
+func TestGetPhotosOf(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotosOf(fclient, "87944415@N00", GetPhotosOfOptionalArgs{OwnerId: "47058503995@N01", Page: 2})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getPhotosOf")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "87944415@N00")
+	flickr.Expect(t, fclient.Args.Get("owner_id"), "47058503995@N01")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	_, ok := fclient.Args["extras"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, resp.Photos.Total, 881)
+	flickr.Expect(t, resp.Photos.Photos[1].Secret, "b123456")
+}
+
+func TestGetPublicPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, body, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPublicPhotos(fclient, "47058503995@N01", GetPublicPhotosOptionalArgs{
+		SafeSearch: Moderate,
+		Extras:     flickr.Extras{flickr.ExtraOwnerName},
+		PerPage:    10,
 	})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getPublicPhotos")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "47058503995@N01")
+	flickr.Expect(t, fclient.Args.Get("safe_search"), "2")
+	flickr.Expect(t, fclient.Args.Get("extras"), "owner_name")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "10")
+	_, ok := fclient.Args["page"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, len(resp.Photos.Photos), 4)
+	flickr.Expect(t, resp.Photos.Photos[3].Title, "00_tall")
+}
+
+func TestPhotoURL(t *testing.T) {
+	photo := Photo{Id: "2636", Secret: "a123456", Server: "2", OriginalSecret: "b", OriginalFormat: "png"}
+	url, err := photo.URL(flickr.SizeSmall320)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, url, "https://live.staticflickr.com/2/2636_a123456_n.jpg")
+	url, err = photo.URL(flickr.SizeOriginal)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, url, "https://live.staticflickr.com/2/2636_b_o.png")
 }
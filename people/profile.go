@@ -0,0 +1,222 @@
+package people
+
+import (
+	"encoding/xml"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// User is a user as returned by the find methods
+type User struct {
+	Nsid     string `xml:"nsid,attr"`
+	Username string `xml:"username"`
+}
+
+type UserResponse struct {
+	flickr.BasicResponse
+	User User `xml:"user"`
+}
+
+// PersonPhotos are the counters of the photos of a user
+type PersonPhotos struct {
+	// Upload date of the first photo
+	FirstDate time.Time `xml:"-"`
+	// Taken date of the oldest photo
+	FirstDateTaken time.Time `xml:"-"`
+	Count          int       `xml:"count"`
+	Views          int       `xml:"views"`
+}
+
+// UnmarshalXML decodes firstdate, a unix timestamp, and firstdatetaken, a
+// mysql datetime
+func (p *PersonPhotos) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type personPhotos PersonPhotos
+	raw := struct {
+		*personPhotos
+		FirstDate      string `xml:"firstdate"`
+		FirstDateTaken string `xml:"firstdatetaken"`
+	}{personPhotos: (*personPhotos)(p)}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	p.FirstDate = flickr.ParseUnixTime(raw.FirstDate)
+	p.FirstDateTaken = flickr.ParseMySQLDatetime(raw.FirstDateTaken)
+	return nil
+}
+
+// Person is the profile of a user, private fields like MboxSha1Sum are only
+// returned to the calling user
+type Person struct {
+	Nsid        string `xml:"nsid,attr"`
+	IsPro       bool   `xml:"ispro,attr"`
+	IconServer  string `xml:"iconserver,attr"`
+	IconFarm    int    `xml:"iconfarm,attr"`
+	PathAlias   string `xml:"path_alias,attr"`
+	Username    string `xml:"username"`
+	RealName    string `xml:"realname"`
+	MboxSha1Sum string `xml:"mbox_sha1sum"`
+	Location    string `xml:"location"`
+	Timezone    struct {
+		Label  string `xml:"label,attr"`
+		Offset string `xml:"offset,attr"`
+		Id     string `xml:"timezone_id,attr"`
+	} `xml:"timezone"`
+	Description string       `xml:"description"`
+	PhotosURL   string       `xml:"photosurl"`
+	ProfileURL  string       `xml:"profileurl"`
+	MobileURL   string       `xml:"mobileurl"`
+	Photos      PersonPhotos `xml:"photos"`
+}
+
+// BuddyIconURL returns the URL of the buddy icon of the user
+func (p *Person) BuddyIconURL() string {
+	return flickr.BuddyIconURL(p.IconFarm, p.IconServer, p.Nsid)
+}
+
+type PersonResponse struct {
+	flickr.BasicResponse
+	Person Person `xml:"person"`
+}
+
+// Quota is an amount of bytes, Unlimited is set for bandwidth only
+type Quota struct {
+	MaxBytes       int64 `xml:"maxbytes,attr"`
+	UsedBytes      int64 `xml:"usedbytes,attr"`
+	RemainingBytes int64 `xml:"remainingbytes,attr"`
+	Unlimited      bool  `xml:"unlimited,attr"`
+}
+
+// UploadStatus holds the upload limits of a user, remaining sets and videos
+// are a number or "lots"
+type UploadStatus struct {
+	Nsid      string `xml:"id,attr"`
+	IsPro     bool   `xml:"ispro,attr"`
+	Username  string `xml:"username"`
+	Bandwidth Quota  `xml:"bandwidth"`
+	FileSize  Quota  `xml:"filesize"`
+	VideoSize Quota  `xml:"videosize"`
+	Sets      struct {
+		Created   int    `xml:"created,attr"`
+		Remaining string `xml:"remaining,attr"`
+	} `xml:"sets"`
+	Videos struct {
+		Uploaded  int    `xml:"uploaded,attr"`
+		Remaining string `xml:"remaining,attr"`
+	} `xml:"videos"`
+}
+
+// CanUpload tells whether a file of size bytes fits both the maximum file
+// size and the remaining bandwidth of the user
+func (s *UploadStatus) CanUpload(size int64, isVideo bool) bool {
+	max := s.FileSize.MaxBytes
+	if isVideo {
+		max = s.VideoSize.MaxBytes
+	}
+	if max > 0 && size > max {
+		return false
+	}
+	return s.Bandwidth.Unlimited || size <= s.Bandwidth.RemainingBytes
+}
+
+type UploadStatusResponse struct {
+	flickr.BasicResponse
+	User UploadStatus `xml:"user"`
+}
+
+// Limits are the display and upload limits of a user, MaxDuration is in
+// seconds
+type Limits struct {
+	Nsid   string `xml:"nsid,attr"`
+	Photos struct {
+		MaxDisplayPx int   `xml:"maxdisplaypx,attr"`
+		MaxUpload    int64 `xml:"maxupload,attr"`
+	} `xml:"photos"`
+	Videos struct {
+		MaxDuration int   `xml:"maxduration,attr"`
+		MaxUpload   int64 `xml:"maxupload,attr"`
+	} `xml:"videos"`
+}
+
+type LimitsResponse struct {
+	flickr.BasicResponse
+	Person Limits `xml:"person"`
+}
+
+// Return a user's NSID, given their email address
+func FindByEmail(client *flickr.FlickrClient, email string) (*UserResponse, error) {
+	if email == "" {
+		return &UserResponse{}, flickErr.NewError(flickErr.ValidationError, "email is required")
+	}
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.people.findByEmail")
+	client.Args.Set("find_email", email)
+	client.OAuthSign()
+
+	response := &UserResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Return a user's NSID, given their username
+func FindByUsername(client *flickr.FlickrClient, username string) (*UserResponse, error) {
+	if username == "" {
+		return &UserResponse{}, flickErr.NewError(flickErr.ValidationError, "username is required")
+	}
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.people.findByUsername")
+	client.Args.Set("username", username)
+	client.OAuthSign()
+
+	response := &UserResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Get the profile of a user
+func GetInfo(client *flickr.FlickrClient, userId string) (*PersonResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.people.getInfo")
+	client.Args.Set("user_id", userId)
+	client.OAuthSign()
+
+	response := &PersonResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Get the bandwidth and file size limits of the calling user, use
+// UploadStatus.CanUpload to check a file before uploading it.
+// This method requires authentication with 'read' permission.
+func GetUploadStatus(client *flickr.FlickrClient) (*UploadStatusResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.people.getUploadStatus")
+	client.OAuthSign()
+
+	response := &UploadStatusResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Get the display and upload limits of the calling user.
+// This method requires authentication with 'read' permission.
+func GetLimits(client *flickr.FlickrClient) (*LimitsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.people.getLimits")
+	client.OAuthSign()
+
+	response := &LimitsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
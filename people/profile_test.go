@@ -0,0 +1,144 @@
+package people
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func TestFind(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <user id="12037949632@N01" nsid="12037949632@N01">
+    <username>Stewart</username>
+  </user>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := FindByEmail(fclient, "stewart@example.com")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.findByEmail")
+	flickr.Expect(t, fclient.Args.Get("find_email"), "stewart@example.com")
+	flickr.Expect(t, resp.User, User{Nsid: "12037949632@N01", Username: "Stewart"})
+
+	resp, err = FindByUsername(fclient, "Stewart")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.findByUsername")
+	flickr.Expect(t, fclient.Args.Get("username"), "Stewart")
+	flickr.Expect(t, resp.User.Nsid, "12037949632@N01")
+
+	resp, err = FindByEmail(fclient, "")
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+	resp, err = FindByUsername(fclient, "")
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+}
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <person nsid="12037949754@N01" ispro="1" iconserver="122" iconfarm="1" path_alias="bees">
+    <username>bees</username>
+    <realname>Cal Henderson</realname>
+    <mbox_sha1sum>eea6cd28e3d0003ab51b0058a684d94980b727ac</mbox_sha1sum>
+    <location>Vancouver, Canada</location>
+    <timezone label="Pacific Time (US &amp; Canada); Tijuana" offset="-08:00" timezone_id="PST8PDT" />
+    <description>Hi!</description>
+    <photosurl>https://www.flickr.com/photos/bees/</photosurl>
+    <profileurl>https://www.flickr.com/people/bees/</profileurl>
+    <mobileurl>https://m.flickr.com/photostream.gne?id=6</mobileurl>
+    <photos>
+      <firstdatetaken>2001-06-11 16:32:56</firstdatetaken>
+      <firstdate>1071510391</firstdate>
+      <count>449</count>
+      <views>1234</views>
+    </photos>
+  </person>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "12037949754@N01")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getInfo")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "12037949754@N01")
+	person := resp.Person
+	flickr.Expect(t, person.Nsid, "12037949754@N01")
+	flickr.Expect(t, person.IsPro, true)
+	flickr.Expect(t, person.PathAlias, "bees")
+	flickr.Expect(t, person.RealName, "Cal Henderson")
+	flickr.Expect(t, person.Location, "Vancouver, Canada")
+	flickr.Expect(t, person.Timezone.Id, "PST8PDT")
+	flickr.Expect(t, person.PhotosURL, "https://www.flickr.com/photos/bees/")
+	flickr.Expect(t, person.Photos.Count, 449)
+	flickr.Expect(t, person.Photos.Views, 1234)
+	flickr.Expect(t, person.Photos.FirstDate.Equal(time.Unix(1071510391, 0)), true)
+	flickr.Expect(t, person.Photos.FirstDateTaken, time.Date(2001, 6, 11, 16, 32, 56, 0, time.UTC))
+	flickr.Expect(t, person.BuddyIconURL(), "https://farm1.staticflickr.com/122/buddyicons/12037949754@N01.jpg")
+}
+
+func TestGetUploadStatus(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <user id="12037949754@N01" ispro="0">
+    <username>Bees</username>
+    <bandwidth maxbytes="2147483648" maxkb="2097152" usedbytes="383724" usedkb="374"
+      remainingbytes="2147099924" remainingkb="2096777" unlimited="0" />
+    <filesize maxbytes="209715200" maxkb="204800" maxmb="200" />
+    <videosize maxbytes="1073741824" maxkb="1048576" maxmb="1024" />
+    <sets created="27" remaining="lots" />
+    <videos uploaded="5" remaining="lots" />
+  </user>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetUploadStatus(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getUploadStatus")
+	status := resp.User
+	flickr.Expect(t, status.Nsid, "12037949754@N01")
+	flickr.Expect(t, status.Username, "Bees")
+	flickr.Expect(t, status.Bandwidth, Quota{MaxBytes: 2147483648, UsedBytes: 383724, RemainingBytes: 2147099924})
+	flickr.Expect(t, status.FileSize.MaxBytes, int64(209715200))
+	flickr.Expect(t, status.Sets.Created, 27)
+	flickr.Expect(t, status.Videos.Remaining, "lots")
+
+	flickr.Expect(t, status.CanUpload(100<<20, false), true)
+	// too large for a photo but not for a video
+	flickr.Expect(t, status.CanUpload(500<<20, false), false)
+	flickr.Expect(t, status.CanUpload(500<<20, true), true)
+	// not enough bandwidth left
+	status.Bandwidth.RemainingBytes = 1 << 20
+	flickr.Expect(t, status.CanUpload(2<<20, false), false)
+	status.Bandwidth.Unlimited = true
+	flickr.Expect(t, status.CanUpload(2<<20, false), true)
+}
+
+func TestGetLimits(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <person nsid="30135021@N05">
+    <photos maxdisplaypx="1024" maxupload="15728640" />
+    <videos maxduration="90" maxupload="157286400" />
+  </person>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetLimits(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.people.getLimits")
+	flickr.Expect(t, resp.Person.Nsid, "30135021@N05")
+	flickr.Expect(t, resp.Person.Photos.MaxDisplayPx, 1024)
+	flickr.Expect(t, resp.Person.Photos.MaxUpload, int64(15728640))
+	flickr.Expect(t, resp.Person.Videos.MaxDuration, 90)
+	flickr.Expect(t, resp.Person.Videos.MaxUpload, int64(157286400))
+}
@@ -0,0 +1,248 @@
+package flickr
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Size suffixes of the url_*, width_* and height_* extras
+var extraSizes = map[string]PhotoSize{
+	"sq": SizeSquare75,
+	"q":  SizeSquare150,
+	"t":  SizeThumbnail100,
+	"s":  SizeSmall240,
+	"n":  SizeSmall320,
+	"w":  SizeSmall400,
+	"m":  SizeMedium500,
+	"z":  SizeMedium640,
+	"c":  SizeMedium800,
+	"l":  SizeLarge1024,
+	"h":  SizeLarge1600,
+	"k":  SizeLarge2048,
+	"3k": SizeXLarge3K,
+	"4k": SizeXLarge4K,
+	"f":  SizeXLarge4K2to1,
+	"5k": SizeXLarge5K,
+	"6k": SizeXLarge6K,
+	"o":  SizeOriginal,
+}
+
+// ImageURL is the source of a photo in a given size, as returned by the url_* extras
+type ImageURL struct {
+	URL    string
+	Width  int
+	Height int
+}
+
+// MachineTag is a tag in the form namespace:predicate=value
+type MachineTag struct {
+	Namespace string
+	Predicate string
+	Value     string
+}
+
+func (t MachineTag) String() string {
+	return t.Namespace + ":" + t.Predicate + "=" + t.Value
+}
+
+// ParseMachineTag splits a machine tag into its parts, ok is false if tag
+// is not a machine tag
+func ParseMachineTag(tag string) (mt MachineTag, ok bool) {
+	name, value, found := strings.Cut(tag, "=")
+	if !found {
+		return mt, false
+	}
+	namespace, predicate, found := strings.Cut(name, ":")
+	if !found || namespace == "" || predicate == "" {
+		return mt, false
+	}
+	return MachineTag{Namespace: namespace, Predicate: predicate, Value: strings.Trim(value, `"`)}, true
+}
+
+// Photo is an item of the photo lists returned by search, listing, photoset
+// and people methods. Fields other than id, owner, secret, server, farm, title
+// and visibility are only filled when requested through extras, values Flickr
+// sends in a format we can't parse are left to their zero value.
+type Photo struct {
+	Id       string
+	Owner    string
+	Secret   string
+	Server   string
+	Farm     int
+	Title    string
+	IsPublic bool
+	IsFriend bool
+	IsFamily bool
+	// Whether the photo is the primary of the photoset, photoset lists only
+	IsPrimary   bool
+	Description string
+	License     int
+	DateUpload  time.Time
+	DateTaken   TakenDate
+	LastUpdate  time.Time
+	// When the photo was added to the favorites, favorites lists only
+	DateFaved      time.Time
+	OwnerName      string
+	IconServer     string
+	IconFarm       int
+	OriginalFormat string
+	OriginalSecret string
+	OriginalWidth  int
+	OriginalHeight int
+	// Nil if the photo is not geotagged or the geo extra wasn't requested
+	Geo         *Location
+	Tags        []string
+	MachineTags []MachineTag
+	Views       int
+	Media       string
+	PathAlias   string
+	// Sources returned by the url_* extras, indexed by size
+	URLs map[PhotoSize]ImageURL
+}
+
+// UnmarshalXML decodes a photo element converting the extras to their types
+func (p *Photo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = Photo{}
+	geo := Location{}
+	hasGeo := false
+	var taken, granularity, unknown string
+	for _, attr := range start.Attr {
+		switch name, value := attr.Name.Local, attr.Value; name {
+		case "id":
+			p.Id = value
+		case "owner":
+			p.Owner = value
+		case "secret":
+			p.Secret = value
+		case "server":
+			p.Server = value
+		case "farm":
+			p.Farm, _ = strconv.Atoi(value)
+		case "title":
+			p.Title = value
+		case "ispublic":
+			p.IsPublic = value == "1"
+		case "isfriend":
+			p.IsFriend = value == "1"
+		case "isfamily":
+			p.IsFamily = value == "1"
+		case "isprimary":
+			p.IsPrimary = value == "1"
+		case "license":
+			p.License, _ = strconv.Atoi(value)
+		case "dateupload":
+			p.DateUpload = ParseUnixTime(value)
+		case "datetaken":
+			taken = value
+		case "datetakengranularity":
+			granularity = value
+		case "datetakenunknown":
+			unknown = value
+		case "lastupdate":
+			p.LastUpdate = ParseUnixTime(value)
+		case "date_faved":
+			p.DateFaved = ParseUnixTime(value)
+		case "ownername":
+			p.OwnerName = value
+		case "iconserver":
+			p.IconServer = value
+		case "iconfarm":
+			p.IconFarm, _ = strconv.Atoi(value)
+		case "originalformat":
+			p.OriginalFormat = value
+		case "originalsecret":
+			p.OriginalSecret = value
+		case "o_width":
+			p.OriginalWidth, _ = strconv.Atoi(value)
+		case "o_height":
+			p.OriginalHeight, _ = strconv.Atoi(value)
+		case "latitude":
+			geo.Latitude, _ = strconv.ParseFloat(value, 64)
+			hasGeo = hasGeo || geo.Latitude != 0
+		case "longitude":
+			geo.Longitude, _ = strconv.ParseFloat(value, 64)
+			hasGeo = hasGeo || geo.Longitude != 0
+		case "accuracy":
+			accuracy, _ := strconv.Atoi(value)
+			geo.Accuracy = GeoAccuracy(accuracy)
+		case "context":
+			context, _ := strconv.Atoi(value)
+			geo.Context = GeoContext(context)
+		case "place_id":
+			geo.PlaceId = value
+		case "woeid":
+			geo.WoeId = value
+		case "tags":
+			p.Tags = strings.Fields(value)
+		case "machine_tags":
+			for _, tag := range strings.Fields(value) {
+				if mt, ok := ParseMachineTag(tag); ok {
+					p.MachineTags = append(p.MachineTags, mt)
+				}
+			}
+		case "views":
+			p.Views, _ = strconv.Atoi(value)
+		case "media":
+			p.Media = value
+		case "pathalias":
+			p.PathAlias = value
+		default:
+			p.setImageAttr(name, value)
+		}
+	}
+	if taken != "" {
+		p.DateTaken = ParseTakenDate(taken, granularity, unknown)
+	}
+	if hasGeo {
+		p.Geo = &geo
+	}
+
+	var children struct {
+		Description string `xml:"description"`
+	}
+	if err := d.DecodeElement(&children, &start); err != nil {
+		return err
+	}
+	p.Description = children.Description
+	return nil
+}
+
+// Fill URLs from the url_*, width_* and height_* attributes, others are ignored
+func (p *Photo) setImageAttr(name, value string) {
+	kind, suffix, found := strings.Cut(name, "_")
+	if !found {
+		return
+	}
+	size, ok := extraSizes[suffix]
+	if !ok {
+		return
+	}
+
+	if p.URLs == nil {
+		p.URLs = map[PhotoSize]ImageURL{}
+	}
+	image := p.URLs[size]
+	switch kind {
+	case "url":
+		image.URL = value
+	case "width":
+		image.Width, _ = strconv.Atoi(value)
+	case "height":
+		image.Height, _ = strconv.Atoi(value)
+	default:
+		return
+	}
+	p.URLs[size] = image
+}
+
+// URL returns the source of the photo in the given size, either as returned
+// by the url_* extras or built with PhotoURL. The original size requires the
+// original_format extra.
+func (p *Photo) URL(size PhotoSize) (string, error) {
+	if image, ok := p.URLs[size]; ok && image.URL != "" {
+		return image.URL, nil
+	}
+	return PhotoURL(p.Server, p.Id, p.Secret, p.OriginalSecret, p.OriginalFormat, size)
+}
@@ -0,0 +1,88 @@
+package flickr
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestPhotoUnmarshal(t *testing.T) {
+	body := `<photo id="52840975319" owner="123456@N00" secret="16de78c2d0" server="65535" farm="66"
+		title="Duomo" ispublic="1" isfriend="0" isfamily="1" isprimary="1" license="4"
+		dateupload="1682290477" lastupdate="not a date" datetaken="2023-04-23 18:54:13"
+		datetakengranularity="4" datetakenunknown="1" date_faved="1682290600" views="42"
+		tags="milano duomo" machine_tags="geo:lat=45.46 dc:title=&quot;Duomo di Milano&quot; notamachinetag"
+		latitude="45.464211" longitude="9.191383" accuracy="16" context="2" place_id="abc" woeid="718345"
+		width_z="640" height_z="480" url_z="https://live.staticflickr.com/65535/52840975319_16de78c2d0_z.jpg"
+		url_unknown="ignored" media="video">
+		<description>The cathedral</description>
+	</photo>`
+
+	photo := Photo{}
+	err := xml.Unmarshal([]byte(body), &photo)
+	Expect(t, err, nil)
+	Expect(t, photo.Id, "52840975319")
+	Expect(t, photo.Farm, 66)
+	Expect(t, photo.IsPublic, true)
+	Expect(t, photo.IsFriend, false)
+	Expect(t, photo.IsFamily, true)
+	Expect(t, photo.IsPrimary, true)
+	Expect(t, photo.License, 4)
+	Expect(t, photo.Description, "The cathedral")
+	Expect(t, photo.DateUpload.Equal(time.Unix(1682290477, 0)), true)
+	Expect(t, photo.LastUpdate.IsZero(), true)
+	Expect(t, photo.DateTaken, TakenDate{Time: time.Date(2023, 4, 23, 18, 54, 13, 0, time.UTC), Granularity: TakenMonth, Unknown: true})
+	Expect(t, photo.DateFaved.Equal(time.Unix(1682290600, 0)), true)
+	Expect(t, photo.Views, 42)
+	Expect(t, photo.Media, "video")
+
+	Expect(t, len(photo.Tags), 2)
+	Expect(t, photo.Tags[1], "duomo")
+	Expect(t, len(photo.MachineTags), 2)
+	Expect(t, photo.MachineTags[0], MachineTag{Namespace: "geo", Predicate: "lat", Value: "45.46"})
+	// values with spaces are split by Flickr in the machine_tags extra
+	Expect(t, photo.MachineTags[1].Value, "Duomo")
+
+	Expect(t, photo.Geo != nil, true)
+	Expect(t, photo.Geo.Latitude, 45.464211)
+	Expect(t, photo.Geo.Longitude, 9.191383)
+	Expect(t, photo.Geo.Accuracy, StreetLevel)
+	Expect(t, photo.Geo.Context, Outdoors)
+	Expect(t, photo.Geo.WoeId, "718345")
+
+	Expect(t, len(photo.URLs), 1)
+	Expect(t, photo.URLs[SizeMedium640], ImageURL{
+		URL: "https://live.staticflickr.com/65535/52840975319_16de78c2d0_z.jpg", Width: 640, Height: 480})
+}
+
+func TestParseMachineTag(t *testing.T) {
+	mt, ok := ParseMachineTag("upload:checksum=abc123")
+	Expect(t, ok, true)
+	Expect(t, mt, MachineTag{Namespace: "upload", Predicate: "checksum", Value: "abc123"})
+	Expect(t, mt.String(), "upload:checksum=abc123")
+
+	mt, ok = ParseMachineTag(`dc:title="Duomo di Milano"`)
+	Expect(t, ok, true)
+	Expect(t, mt.Value, "Duomo di Milano")
+
+	for _, tag := range []string{"milano", "title=Duomo", ":title=Duomo", "dc:=Duomo"} {
+		_, ok = ParseMachineTag(tag)
+		Expect(t, ok, false)
+	}
+}
+
+func TestPhotoModelURL(t *testing.T) {
+	photo := Photo{Id: "2636", Secret: "a123456", Server: "2", URLs: map[PhotoSize]ImageURL{
+		SizeLarge1600: {URL: "https://live.staticflickr.com/2/2636_f00_h.jpg"},
+	}}
+	url, err := photo.URL(SizeLarge1600)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/2/2636_f00_h.jpg")
+
+	url, err = photo.URL(SizeSmall320)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/2/2636_a123456_n.jpg")
+
+	_, err = photo.URL(SizeOriginal)
+	Expect(t, err != nil, true)
+}
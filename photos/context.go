@@ -0,0 +1,122 @@
+package photos
+
+import (
+	"encoding/xml"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+// ContextPhoto is a photo next to another one in a photostream, set, pool or
+// favorites list, along with the extras requested if any
+type ContextPhoto struct {
+	flickr.Photo
+	// Photo page, relative to the Flickr site
+	Url     string
+	Thumb   string
+	IsFaved bool
+}
+
+// UnmarshalXML decodes the context attributes and the photo as flickr.Photo
+func (p *ContextPhoto) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "url":
+			p.Url = attr.Value
+		case "thumb":
+			p.Thumb = attr.Value
+		case "is_faved":
+			p.IsFaved = attr.Value == "1"
+		}
+	}
+	return d.DecodeElement(&p.Photo, &start)
+}
+
+// ContextResponse holds the photos before and after a given one, Flickr
+// returns a photo with ID "0" at the edges: use PrevPhotos and NextPhotos
+// to get only the actual ones
+type ContextResponse struct {
+	flickr.BasicResponse
+	Count int            `xml:"count"`
+	Prev  []ContextPhoto `xml:"prevphoto"`
+	Next  []ContextPhoto `xml:"nextphoto"`
+}
+
+func actualPhotos(list []ContextPhoto) []ContextPhoto {
+	var ret []ContextPhoto
+	for _, p := range list {
+		if p.Id != "" && p.Id != "0" {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// PrevPhotos returns the photos before the given one, closest first
+func (r *ContextResponse) PrevPhotos() []ContextPhoto {
+	return actualPhotos(r.Prev)
+}
+
+// NextPhotos returns the photos after the given one, closest first
+func (r *ContextResponse) NextPhotos() []ContextPhoto {
+	return actualPhotos(r.Next)
+}
+
+// SetContext is a photoset a photo belongs to
+type SetContext struct {
+	Id           string `xml:"id,attr"`
+	Title        string `xml:"title,attr"`
+	Primary      string `xml:"primary,attr"`
+	Secret       string `xml:"secret,attr"`
+	Server       string `xml:"server,attr"`
+	Farm         string `xml:"farm,attr"`
+	ViewCount    int    `xml:"view_count,attr"`
+	CommentCount int    `xml:"comment_count,attr"`
+	CountPhoto   int    `xml:"count_photo,attr"`
+	CountVideo   int    `xml:"count_video,attr"`
+}
+
+// PoolContext is a group pool a photo belongs to
+type PoolContext struct {
+	Id         string `xml:"id,attr"`
+	Title      string `xml:"title,attr"`
+	Url        string `xml:"url,attr"`
+	IconServer string `xml:"iconserver,attr"`
+	IconFarm   string `xml:"iconfarm,attr"`
+	Members    int    `xml:"members,attr"`
+	PoolCount  int    `xml:"pool_count,attr"`
+}
+
+type AllContextsResponse struct {
+	flickr.BasicResponse
+	Sets  []SetContext  `xml:"set"`
+	Pools []PoolContext `xml:"pool"`
+}
+
+// GetContext returns the photos before and after the given one in its
+// owner's photostream
+func GetContext(client *flickr.FlickrClient, photoId string) (*ContextResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.getContext")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &ContextResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetAllContexts returns all the photosets and group pools a photo belongs to
+func GetAllContexts(client *flickr.FlickrClient, photoId string) (*AllContextsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.getAllContexts")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &AllContextsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,59 @@
+package photos
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func TestGetContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <count>12</count>
+  <prevphoto id="2980" secret="973da1e709" server="2" farm="1" title="boo!" url="/photos/bees/2980/" thumb="https://live.staticflickr.com/2/2980_973da1e709_s.jpg" license="0" media="photo" />
+  <nextphoto id="2985" secret="059b664012" server="2" farm="1" title="Amsterdam Amstel" url="/photos/bees/2985/" thumb="https://live.staticflickr.com/2/2985_059b664012_s.jpg" license="0" media="photo" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetContext(fclient, "2983")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getContext")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2983")
+	flickr.Expect(t, resp.Count, 12)
+	flickr.Expect(t, resp.PrevPhotos()[0].Id, "2980")
+	flickr.Expect(t, resp.NextPhotos()[0].Title, "Amsterdam Amstel")
+	flickr.Expect(t, resp.NextPhotos()[0].Url, "/photos/bees/2985/")
+	flickr.Expect(t, resp.NextPhotos()[0].Farm, 1)
+
+	// first photo of the stream
+	resp = &ContextResponse{
+		Prev: []ContextPhoto{{Photo: flickr.Photo{Id: "0"}}},
+		Next: []ContextPhoto{{Photo: flickr.Photo{Id: "2985"}}},
+	}
+	flickr.Expect(t, len(resp.PrevPhotos()), 0)
+	flickr.Expect(t, len(resp.NextPhotos()), 1)
+}
+
+func TestGetAllContexts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <set id="392" title="Bees" primary="2983" secret="abc" server="2" farm="1" view_count="10" comment_count="1" count_photo="5" count_video="0" />
+  <set id="393" title="Amsterdam" />
+  <pool id="34427465471@N01" title="FlickrDiscuss" url="/groups/discuss/pool/" iconserver="1" iconfarm="1" members="1200" pool_count="300" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetAllContexts(fclient, "2983")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getAllContexts")
+	flickr.Expect(t, len(resp.Sets), 2)
+	flickr.Expect(t, resp.Sets[0].Title, "Bees")
+	flickr.Expect(t, resp.Sets[0].CountPhoto, 5)
+	flickr.Expect(t, len(resp.Pools), 1)
+	flickr.Expect(t, resp.Pools[0].Id, "34427465471@N01")
+	flickr.Expect(t, resp.Pools[0].Members, 1200)
+}
@@ -0,0 +1,147 @@
+package photos
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+// ExifEntry is a single EXIF, TIFF or maker note tag as returned by Flickr
+type ExifEntry struct {
+	TagSpace   string `xml:"tagspace,attr"`
+	TagSpaceId int    `xml:"tagspaceid,attr"`
+	Tag        string `xml:"tag,attr"`
+	Label      string `xml:"label,attr"`
+	Raw        string `xml:"raw"`
+	// Human readable value, only present for some tags
+	Clean string `xml:"clean"`
+}
+
+type PhotoExif struct {
+	Id     string      `xml:"id,attr"`
+	Secret string      `xml:"secret,attr"`
+	Server string      `xml:"server,attr"`
+	Farm   string      `xml:"farm,attr"`
+	Camera string      `xml:"camera,attr"`
+	Exif   []ExifEntry `xml:"exif"`
+}
+
+type PhotoExifResponse struct {
+	flickr.BasicResponse
+	Photo PhotoExif `xml:"photo"`
+}
+
+// CameraSettings holds the camera and exposure values parsed from EXIF,
+// zero values mean the tag was missing or couldn't be parsed
+type CameraSettings struct {
+	Make  string
+	Model string
+	Lens  string
+	// Focal length in millimeters
+	FocalLength float64
+	// Aperture as f-number, ex. 2.8
+	Aperture float64
+	// Shutter speed as reported by the camera, ex. "1/250"
+	ShutterSpeed string
+	ExposureTime time.Duration
+	ISO          int
+	// EXIF dates carry no timezone, they're returned as UTC
+	DateTimeOriginal time.Time
+}
+
+// Tags holding the lens name, in order of preference
+var lensTags = []string{"LensModel", "Lens", "LensInfo", "LensType"}
+
+// Get returns the first entry with the given tag name, in any tagspace
+func (p *PhotoExif) Get(tag string) (ExifEntry, bool) {
+	for _, e := range p.Exif {
+		if e.Tag == tag {
+			return e, true
+		}
+	}
+	return ExifEntry{}, false
+}
+
+// Return the raw value of tag, or "" if missing
+func (p *PhotoExif) raw(tag string) string {
+	e, _ := p.Get(tag)
+	return strings.TrimSpace(e.Raw)
+}
+
+// Settings parses camera and exposure values from the EXIF entries
+func (p *PhotoExif) Settings() CameraSettings {
+	s := CameraSettings{
+		Make:         p.raw("Make"),
+		Model:        p.raw("Model"),
+		ShutterSpeed: p.raw("ExposureTime"),
+	}
+	for _, tag := range lensTags {
+		if s.Lens = p.raw(tag); s.Lens != "" {
+			break
+		}
+	}
+
+	s.FocalLength = parseExifNumber(p.raw("FocalLength"))
+	s.Aperture = parseExifNumber(p.raw("FNumber"))
+	if s.ShutterSpeed != "" {
+		seconds := parseExifNumber(s.ShutterSpeed)
+		s.ExposureTime = time.Duration(seconds * float64(time.Second))
+	}
+
+	iso := p.raw("ISO")
+	if iso == "" {
+		iso = p.raw("ISOSpeedRatings")
+	}
+	s.ISO = int(parseExifNumber(iso))
+
+	for _, layout := range []string{"2006:01:02 15:04:05", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, p.raw("DateTimeOriginal")); err == nil {
+			s.DateTimeOriginal = t
+			break
+		}
+	}
+
+	return s
+}
+
+// Parse values like "50.0 mm", "f/2.8", "1/250" or "100, 100" returning 0 on errors
+func parseExifNumber(value string) float64 {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "f/")
+	if i := strings.IndexAny(value, " ,"); i >= 0 {
+		value = value[:i]
+	}
+	if num, den, ok := strings.Cut(value, "/"); ok {
+		n, err1 := strconv.ParseFloat(num, 64)
+		d, err2 := strconv.ParseFloat(den, 64)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0
+		}
+		return n / d
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// GetExif retrieves the EXIF, TIFF and maker note tags of a photo, use
+// Photo.Settings to get the most common camera values already parsed.
+// secret is optional, passing it skips the permissions check.
+func GetExif(client *flickr.FlickrClient, id string, secret string) (*PhotoExifResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.getExif")
+	client.Args.Set("photo_id", id)
+	if secret != "" {
+		client.Args.Set("secret", secret)
+	}
+	client.OAuthSign()
+
+	response := &PhotoExifResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,92 @@
+package photos
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+const photoExif = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="52435165562" secret="abc" server="65535" farm="66" camera="Sony ILCE-7M4">
+    <exif tagspace="IFD0" tagspaceid="0" tag="Make" label="Make">
+      <raw>Sony</raw>
+    </exif>
+    <exif tagspace="IFD0" tagspaceid="0" tag="Model" label="Model">
+      <raw>ILCE-7M4</raw>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="ExposureTime" label="Exposure">
+      <raw>1/250</raw>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="FNumber" label="Aperture">
+      <raw>2.8</raw>
+      <clean>f/2.8</clean>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="ISO" label="ISO Speed">
+      <raw>400</raw>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="DateTimeOriginal" label="Date and Time (Original)">
+      <raw>2022:09:24 08:07:22</raw>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="FocalLength" label="Focal Length">
+      <raw>50.0 mm</raw>
+    </exif>
+    <exif tagspace="ExifIFD" tagspaceid="0" tag="LensModel" label="Lens Model">
+      <raw>50mm F1.4 DG HSM | Art 014</raw>
+    </exif>
+  </photo>
+</rsp>`
+
+func TestGetExif(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photoExif, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetExif(fclient, "52435165562", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getExif")
+	_, ok := fclient.Args["secret"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, resp.Photo.Camera, "Sony ILCE-7M4")
+	flickr.Expect(t, len(resp.Photo.Exif), 8)
+	flickr.Expect(t, resp.Photo.Exif[3].Clean, "f/2.8")
+
+	entry, ok := resp.Photo.Get("ISO")
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, entry.Label, "ISO Speed")
+	_, ok = resp.Photo.Get("GPSLatitude")
+	flickr.Expect(t, ok, false)
+
+	s := resp.Photo.Settings()
+	flickr.Expect(t, s.Make, "Sony")
+	flickr.Expect(t, s.Model, "ILCE-7M4")
+	flickr.Expect(t, s.Lens, "50mm F1.4 DG HSM | Art 014")
+	flickr.Expect(t, s.FocalLength, 50.0)
+	flickr.Expect(t, s.Aperture, 2.8)
+	flickr.Expect(t, s.ShutterSpeed, "1/250")
+	flickr.Expect(t, s.ExposureTime, 4*time.Millisecond)
+	flickr.Expect(t, s.ISO, 400)
+	flickr.Expect(t, s.DateTimeOriginal, time.Date(2022, 9, 24, 8, 7, 22, 0, time.UTC))
+
+	// missing tags leave zero values
+	s = (&PhotoExif{}).Settings()
+	flickr.Expect(t, s, CameraSettings{})
+}
+
+func TestParseExifNumber(t *testing.T) {
+	cases := map[string]float64{
+		"50.0 mm":  50,
+		"f/2.8":    2.8,
+		"1/250":    0.004,
+		"100, 100": 100,
+		"30":       30,
+		"1/0":      0,
+		"n/a":      0,
+		"":         0,
+	}
+	for value, expected := range cases {
+		flickr.Expect(t, parseExifNumber(value), expected)
+	}
+}
@@ -0,0 +1,253 @@
+// Package geo wraps the flickr.photos.geo API methods, used to read and change
+// the location of photos and who can see it
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+	"gopkg.in/masci/flickr.v3/photos"
+)
+
+type LocationResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id       string          `xml:"id,attr"`
+		Location flickr.Location `xml:"location"`
+	} `xml:"photo"`
+}
+
+// Perms tells who can see the location of a photo
+type Perms = photos.GeoPerms
+
+type PermsResponse struct {
+	flickr.BasicResponse
+	Perms Perms `xml:"perms"`
+}
+
+type PhotosResponse struct {
+	flickr.BasicResponse
+	Photos photos.PhotoList `xml:"photos"`
+}
+
+// Check coordinates and accuracy are in the ranges accepted by Flickr
+func validate(lat, lon float64, accuracy flickr.GeoAccuracy) error {
+	var problems []string
+	if !flickr.ValidCoordinates(lat, lon) {
+		problems = append(problems, fmt.Sprintf("coordinates %v,%v out of range", lat, lon))
+	}
+	if !accuracy.Valid() {
+		problems = append(problems, fmt.Sprintf("accuracy %d out of range", accuracy))
+	}
+	if len(problems) > 0 {
+		return flickErr.NewError(flickErr.ValidationError, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validateContext(context flickr.GeoContext) error {
+	if context < flickr.NoContextSpecified || context > flickr.Outdoors {
+		return flickErr.NewError(flickErr.ValidationError, fmt.Sprintf("unknown geo context %d", context))
+	}
+	return nil
+}
+
+func setCoordinates(client *flickr.FlickrClient, lat, lon float64, accuracy flickr.GeoAccuracy) {
+	client.Args.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	client.Args.Set("lon", strconv.FormatFloat(lon, 'f', -1, 64))
+	if accuracy != flickr.NoAccuracySpecified {
+		client.Args.Set("accuracy", strconv.Itoa(int(accuracy)))
+	}
+}
+
+// GetLocation returns the geo data of a photo along with its places hierarchy
+func GetLocation(client *flickr.FlickrClient, photoId string) (*LocationResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.getLocation")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &LocationResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SetLocation sets the geo data of a photo, accuracy and context are optional.
+// This method requires authentication with 'write' permission.
+func SetLocation(client *flickr.FlickrClient, photoId string, lat, lon float64,
+	accuracy flickr.GeoAccuracy, context flickr.GeoContext) (*flickr.BasicResponse, error) {
+	if err := validate(lat, lon, accuracy); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+	if err := validateContext(context); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.setLocation")
+	client.Args.Set("photo_id", photoId)
+	setCoordinates(client, lat, lon, accuracy)
+	if context != flickr.NoContextSpecified {
+		client.Args.Set("context", strconv.Itoa(int(context)))
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// RemoveLocation removes the geo data of a photo.
+// This method requires authentication with 'write' permission.
+func RemoveLocation(client *flickr.FlickrClient, photoId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.removeLocation")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SetContext tells whether a geotagged photo was taken indoors or outdoors.
+// This method requires authentication with 'write' permission.
+func SetContext(client *flickr.FlickrClient, photoId string, context flickr.GeoContext) (*flickr.BasicResponse, error) {
+	if err := validateContext(context); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.setContext")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("context", strconv.Itoa(int(context)))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetPerms returns who can see the location of a photo.
+// This method requires authentication with 'read' permission.
+func GetPerms(client *flickr.FlickrClient, photoId string) (*PermsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.getPerms")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &PermsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+func boolArg(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// SetPerms sets who can see the location of a photo, the Id field of perms
+// is ignored.
+// This method requires authentication with 'write' permission.
+func SetPerms(client *flickr.FlickrClient, photoId string, perms Perms) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.setPerms")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("is_public", boolArg(perms.IsPublic))
+	client.Args.Set("is_contact", boolArg(perms.IsContact))
+	client.Args.Set("is_friend", boolArg(perms.IsFriend))
+	client.Args.Set("is_family", boolArg(perms.IsFamily))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// BatchCorrectLocation moves all the calling user's photos geotagged at the given
+// coordinates and accuracy to the place identified by either placeId or woeId.
+// This method requires authentication with 'write' permission.
+func BatchCorrectLocation(client *flickr.FlickrClient, lat, lon float64, accuracy flickr.GeoAccuracy,
+	placeId, woeId string) (*flickr.BasicResponse, error) {
+	if err := validate(lat, lon, accuracy); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+	if accuracy == flickr.NoAccuracySpecified {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError, "accuracy is required")
+	}
+	if placeId == "" && woeId == "" {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError,
+			"either place ID or WOE ID is required")
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.batchCorrectLocation")
+	setCoordinates(client, lat, lon, accuracy)
+	if placeId != "" {
+		client.Args.Set("place_id", placeId)
+	}
+	if woeId != "" {
+		client.Args.Set("woe_id", woeId)
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+type PhotosForLocationOptionalArgs struct {
+	Accuracy flickr.GeoAccuracy // defaults to street level
+	Extras   flickr.Extras
+	PerPage  int // up to 500
+	Page     int
+}
+
+// PhotosForLocation returns the calling user's photos geotagged at the given
+// coordinates.
+// This method requires authentication with 'read' permission.
+func PhotosForLocation(client *flickr.FlickrClient, lat, lon float64,
+	opts PhotosForLocationOptionalArgs) (*PhotosResponse, error) {
+	if err := validate(lat, lon, opts.Accuracy); err != nil {
+		return &PhotosResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.geo.photosForLocation")
+	setCoordinates(client, lat, lon, opts.Accuracy)
+	if len(opts.Extras) > 0 {
+		client.Args.Set("extras", opts.Extras.String())
+	}
+	if opts.PerPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page > 0 {
+		client.Args.Set("page", strconv.Itoa(opts.Page))
+	}
+	client.OAuthSign()
+
+	response := &PhotosResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,158 @@
+package geo
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+const okResponse = `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`
+
+func TestGetLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="123">
+    <location latitude="45.464203" longitude="9.189982" accuracy="16" context="2" place_id="h3e5Uq5WUb7Y9zA" woeid="22487055">
+      <neighbourhood place_id="h3e5Uq5WUb7Y9zA" woeid="22487055">Duomo</neighbourhood>
+      <locality place_id="x8BUfulQVbPS5Tc" woeid="718345">Milan</locality>
+      <county place_id="Kj.qxmtQUL_tEaWW" woeid="12591726">Milano</county>
+      <region place_id="gIZiWpBQUL_xzEb" woeid="7153331">Lombardy</region>
+      <country place_id="Y_8WmlVTUb6kKv4" woeid="23424853">Italy</country>
+    </location>
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetLocation(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.getLocation")
+	loc := resp.Photo.Location
+	flickr.Expect(t, resp.Photo.Id, "123")
+	flickr.Expect(t, loc.Latitude, 45.464203)
+	flickr.Expect(t, loc.Longitude, 9.189982)
+	flickr.Expect(t, loc.Accuracy, flickr.StreetLevel)
+	flickr.Expect(t, loc.Context, flickr.Outdoors)
+	flickr.Expect(t, loc.Locality.Name, "Milan")
+	flickr.Expect(t, loc.Locality.WoeId, "718345")
+	flickr.Expect(t, loc.Country.PlaceId, "Y_8WmlVTUb6kKv4")
+}
+
+func TestSetLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, okResponse, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SetLocation(fclient, "123", 45.4642, 9.19, flickr.CityLevel, flickr.Indoors)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.setLocation")
+	flickr.Expect(t, fclient.Args.Get("lat"), "45.4642")
+	flickr.Expect(t, fclient.Args.Get("lon"), "9.19")
+	flickr.Expect(t, fclient.Args.Get("accuracy"), "11")
+	flickr.Expect(t, fclient.Args.Get("context"), "1")
+
+	_, err = SetLocation(fclient, "123", 45.4642, 9.19, flickr.NoAccuracySpecified, flickr.NoContextSpecified)
+	flickr.Expect(t, err, nil)
+	_, ok := fclient.Args["accuracy"]
+	flickr.Expect(t, ok, false)
+	_, ok = fclient.Args["context"]
+	flickr.Expect(t, ok, false)
+
+	_, err = SetLocation(fclient, "123", 91, 9.19, 0, 0)
+	flickr.ExpectValidationError(t, err)
+	_, err = SetLocation(fclient, "123", 45, 181, 0, 0)
+	flickr.ExpectValidationError(t, err)
+	_, err = SetLocation(fclient, "123", 45, 9, 17, 0)
+	flickr.ExpectValidationError(t, err)
+	_, err = SetLocation(fclient, "123", 45, 9, 0, 3)
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestRemoveLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, okResponse, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := RemoveLocation(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.removeLocation")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "123")
+}
+
+func TestSetContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, okResponse, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := SetContext(fclient, "123", flickr.Outdoors)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("context"), "2")
+
+	_, err = SetContext(fclient, "123", -1)
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestPerms(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><perms id="123" ispublic="0" iscontact="0" isfriend="1" isfamily="1" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPerms(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Perms, Perms{Id: "123", IsFriend: true, IsFamily: true})
+
+	_, err = SetPerms(fclient, "123", Perms{IsPublic: true})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.setPerms")
+	flickr.Expect(t, fclient.Args.Get("is_public"), "1")
+	flickr.Expect(t, fclient.Args.Get("is_family"), "0")
+}
+
+func TestBatchCorrectLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, okResponse, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := BatchCorrectLocation(fclient, 45.46, 9.19, flickr.StreetLevel, "", "718345")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.batchCorrectLocation")
+	flickr.Expect(t, fclient.Args.Get("woe_id"), "718345")
+	_, ok := fclient.Args["place_id"]
+	flickr.Expect(t, ok, false)
+
+	_, err = BatchCorrectLocation(fclient, 45.46, 9.19, flickr.StreetLevel, "", "")
+	flickr.ExpectValidationError(t, err)
+	_, err = BatchCorrectLocation(fclient, 45.46, 9.19, flickr.NoAccuracySpecified, "x8BUfulQVbPS5Tc", "")
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestPhotosForLocation(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="1">
+    <photo id="123" owner="12345@N00" secret="abc" server="65535" farm="66" title="Duomo" ispublic="1" isfriend="0" isfamily="0" />
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := PhotosForLocation(fclient, 45.46, 9.19, PhotosForLocationOptionalArgs{Extras: flickr.Extras{flickr.ExtraGeo, flickr.ExtraTags}, Page: 1})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.geo.photosForLocation")
+	flickr.Expect(t, fclient.Args.Get("extras"), "geo,tags")
+	flickr.Expect(t, fclient.Args.Get("page"), "1")
+	flickr.Expect(t, resp.Photos.Total, 1)
+	flickr.Expect(t, resp.Photos.Photos[0].Title, "Duomo")
+
+	resp, err = PhotosForLocation(fclient, -91, 9.19, PhotosForLocationOptionalArgs{})
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+}
@@ -0,0 +1,204 @@
+// Package licenses wraps the flickr.photos.licenses API methods and builds
+// the attribution credits required by the licenses of photos
+package licenses
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"strconv"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+	"gopkg.in/masci/flickr.v3/photos"
+)
+
+// License is a license photos can be published under, the flags are not
+// returned by Flickr and are filled from the known terms of each license
+type License struct {
+	Id   photos.LicenseId `xml:"id,attr"`
+	Name string           `xml:"name,attr"`
+	URL  string           `xml:"url,attr"`
+	// Whether the photo can be used for commercial purposes
+	Commercial bool `xml:"-"`
+	// Whether the photo can be modified
+	Derivatives bool `xml:"-"`
+	// Whether derivative works must be shared under the same license
+	ShareAlike bool `xml:"-"`
+	// Whether the author must be credited
+	Attribution bool `xml:"-"`
+}
+
+// Licenses known at the time of writing, as returned by GetInfo
+var known = map[photos.LicenseId]License{
+	photos.AllRightsReserved:            {Name: "All Rights Reserved"},
+	photos.CCByNcSa2:                    {Name: "Attribution-NonCommercial-ShareAlike License", URL: "https://creativecommons.org/licenses/by-nc-sa/2.0/", Derivatives: true, ShareAlike: true, Attribution: true},
+	photos.CCByNc2:                      {Name: "Attribution-NonCommercial License", URL: "https://creativecommons.org/licenses/by-nc/2.0/", Derivatives: true, Attribution: true},
+	photos.CCByNcNd2:                    {Name: "Attribution-NonCommercial-NoDerivs License", URL: "https://creativecommons.org/licenses/by-nc-nd/2.0/", Attribution: true},
+	photos.CCBy2:                        {Name: "Attribution License", URL: "https://creativecommons.org/licenses/by/2.0/", Commercial: true, Derivatives: true, Attribution: true},
+	photos.CCBySa2:                      {Name: "Attribution-ShareAlike License", URL: "https://creativecommons.org/licenses/by-sa/2.0/", Commercial: true, Derivatives: true, ShareAlike: true, Attribution: true},
+	photos.CCByNd2:                      {Name: "Attribution-NoDerivs License", URL: "https://creativecommons.org/licenses/by-nd/2.0/", Commercial: true, Attribution: true},
+	photos.NoKnownCopyrightRestrictions: {Name: "No known copyright restrictions", URL: "https://www.flickr.com/commons/usage/", Commercial: true, Derivatives: true},
+	photos.USGovernmentWork:             {Name: "United States Government Work", URL: "http://www.usa.gov/copyright.shtml", Commercial: true, Derivatives: true},
+	photos.PublicDomainDedication:       {Name: "Public Domain Dedication (CC0)", URL: "https://creativecommons.org/publicdomain/zero/1.0/", Commercial: true, Derivatives: true},
+	photos.PublicDomainMark:             {Name: "Public Domain Mark", URL: "https://creativecommons.org/publicdomain/mark/1.0/", Commercial: true, Derivatives: true},
+	photos.CCBy4:                        {Name: "CC BY 4.0", URL: "https://creativecommons.org/licenses/by/4.0/", Commercial: true, Derivatives: true, Attribution: true},
+	photos.CCBySa4:                      {Name: "CC BY-SA 4.0", URL: "https://creativecommons.org/licenses/by-sa/4.0/", Commercial: true, Derivatives: true, ShareAlike: true, Attribution: true},
+	photos.CCByNd4:                      {Name: "CC BY-ND 4.0", URL: "https://creativecommons.org/licenses/by-nd/4.0/", Commercial: true, Attribution: true},
+	photos.CCByNc4:                      {Name: "CC BY-NC 4.0", URL: "https://creativecommons.org/licenses/by-nc/4.0/", Derivatives: true, Attribution: true},
+	photos.CCByNcSa4:                    {Name: "CC BY-NC-SA 4.0", URL: "https://creativecommons.org/licenses/by-nc-sa/4.0/", Derivatives: true, ShareAlike: true, Attribution: true},
+	photos.CCByNcNd4:                    {Name: "CC BY-NC-ND 4.0", URL: "https://creativecommons.org/licenses/by-nc-nd/4.0/", Attribution: true},
+}
+
+// Lookup returns a known license without calling the API, ok is false for
+// licenses added after this package was written: use GetInfo for those
+func Lookup(id photos.LicenseId) (license License, ok bool) {
+	license, ok = known[id]
+	license.Id = id
+	return license, ok
+}
+
+// UnmarshalXML decodes a license and fills its flags
+func (l *License) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type license License
+	raw := license{}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	*l = License(raw)
+	if terms, ok := known[l.Id]; ok {
+		l.Commercial = terms.Commercial
+		l.Derivatives = terms.Derivatives
+		l.ShareAlike = terms.ShareAlike
+		l.Attribution = terms.Attribution
+	}
+	return nil
+}
+
+type InfoResponse struct {
+	flickr.BasicResponse
+	Licenses []License `xml:"licenses>license"`
+}
+
+// LicenseChange is a change of license of a photo, licenses are identified
+// by name and URL
+type LicenseChange struct {
+	Date          time.Time `xml:"-"`
+	OldLicense    string    `xml:"old_license,attr"`
+	OldLicenseURL string    `xml:"old_license_url,attr"`
+	NewLicense    string    `xml:"new_license,attr"`
+	NewLicenseURL string    `xml:"new_license_url,attr"`
+}
+
+// UnmarshalXML decodes the unix timestamp in date_change into Date
+func (c *LicenseChange) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type licenseChange LicenseChange
+	raw := struct {
+		*licenseChange
+		Date string `xml:"date_change,attr"`
+	}{licenseChange: (*licenseChange)(c)}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	c.Date = flickr.ParseUnixTime(raw.Date)
+	return nil
+}
+
+type HistoryResponse struct {
+	flickr.BasicResponse
+	Changes []LicenseChange `xml:"license_history"`
+}
+
+// GetInfo returns the licenses available on Flickr
+func GetInfo(client *flickr.FlickrClient) (*InfoResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.licenses.getInfo")
+	client.OAuthSign()
+
+	response := &InfoResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetLicenseHistory returns the license changes of a photo
+func GetLicenseHistory(client *flickr.FlickrClient, photoId string) (*HistoryResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.licenses.getLicenseHistory")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &HistoryResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Attribution holds what's needed to credit a photo
+type Attribution struct {
+	Title   string
+	Author  string
+	PageURL string
+	License License
+}
+
+// NewAttribution builds the attribution of a photo as returned by
+// photos.GetInfo, the author is the owner's real name or username
+func NewAttribution(info *photos.PhotoInfo) (Attribution, error) {
+	id, err := strconv.Atoi(info.License)
+	if err != nil {
+		return Attribution{}, flickErr.NewError(flickErr.ValidationError, fmt.Sprintf("invalid license %q", info.License))
+	}
+	license, ok := Lookup(photos.LicenseId(id))
+	if !ok {
+		return Attribution{}, flickErr.NewError(flickErr.ValidationError, fmt.Sprintf("unknown license %d", id))
+	}
+
+	author := info.Owner.RealName
+	if author == "" {
+		author = info.Owner.Username
+	}
+	page := info.PhotoPage()
+	if page == "" {
+		page = fmt.Sprintf("https://www.flickr.com/photos/%s/%s/", info.Owner.NSID, info.Id)
+	}
+	return Attribution{Title: info.Title, Author: author, PageURL: page, License: license}, nil
+}
+
+func (a Attribution) title() string {
+	if a.Title == "" {
+		return "Untitled"
+	}
+	return a.Title
+}
+
+// String returns a plain text credit like
+// “Title” by Author (page URL) is licensed under License (license URL)
+func (a Attribution) String() string {
+	credit := "“" + a.title() + "” by " + a.Author + " (" + a.PageURL + ")"
+	if a.License.Id == photos.AllRightsReserved {
+		return credit + ", all rights reserved"
+	}
+	credit += " is licensed under " + a.License.Name
+	if a.License.URL != "" {
+		credit += " (" + a.License.URL + ")"
+	}
+	return credit
+}
+
+// HTML returns the same credit as String, linking the photo page and the license
+func (a Attribution) HTML() string {
+	credit := fmt.Sprintf(`<a href="%s">%s</a> by %s`,
+		html.EscapeString(a.PageURL), html.EscapeString(a.title()), html.EscapeString(a.Author))
+	if a.License.Id == photos.AllRightsReserved {
+		return credit + ", all rights reserved"
+	}
+	if a.License.URL == "" {
+		return credit + " is licensed under " + html.EscapeString(a.License.Name)
+	}
+	return credit + fmt.Sprintf(` is licensed under <a href="%s" rel="license">%s</a>`,
+		html.EscapeString(a.License.URL), html.EscapeString(a.License.Name))
+}
@@ -0,0 +1,113 @@
+package licenses
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	"gopkg.in/masci/flickr.v3/photos"
+)
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <licenses>
+    <license id="0" name="All Rights Reserved" url="" />
+    <license id="4" name="Attribution License" url="https://creativecommons.org/licenses/by/2.0/" />
+    <license id="15" name="CC BY-NC-SA 4.0" url="https://creativecommons.org/licenses/by-nc-sa/4.0/" />
+    <license id="42" name="Some future license" url="https://example.com/license" />
+  </licenses>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.licenses.getInfo")
+	flickr.Expect(t, len(resp.Licenses), 4)
+	flickr.Expect(t, resp.Licenses[0], License{Id: photos.AllRightsReserved, Name: "All Rights Reserved"})
+	flickr.Expect(t, resp.Licenses[1], License{Id: photos.CCBy2, Name: "Attribution License",
+		URL: "https://creativecommons.org/licenses/by/2.0/", Commercial: true, Derivatives: true, Attribution: true})
+	flickr.Expect(t, resp.Licenses[2].Commercial, false)
+	flickr.Expect(t, resp.Licenses[2].ShareAlike, true)
+	// unknown licenses have no flags
+	flickr.Expect(t, resp.Licenses[3], License{Id: 42, Name: "Some future license", URL: "https://example.com/license"})
+}
+
+func TestGetLicenseHistory(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <license_history date_change="1295918034" old_license="All Rights Reserved" old_license_url=""
+    new_license="Attribution License" new_license_url="https://creativecommons.org/licenses/by/2.0/" />
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetLicenseHistory(fclient, "2733")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.licenses.getLicenseHistory")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2733")
+	flickr.Expect(t, len(resp.Changes), 1)
+	change := resp.Changes[0]
+	flickr.Expect(t, change.Date.Equal(time.Unix(1295918034, 0)), true)
+	flickr.Expect(t, change.OldLicense, "All Rights Reserved")
+	flickr.Expect(t, change.NewLicenseURL, "https://creativecommons.org/licenses/by/2.0/")
+}
+
+func TestLookup(t *testing.T) {
+	license, ok := Lookup(photos.CCByNd4)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, license.Id, photos.CCByNd4)
+	flickr.Expect(t, license.Name, "CC BY-ND 4.0")
+	flickr.Expect(t, license.Commercial, true)
+	flickr.Expect(t, license.Derivatives, false)
+
+	license, ok = Lookup(photos.PublicDomainMark)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, license.Attribution, false)
+
+	for id := photos.AllRightsReserved; id <= photos.CCByNcNd4; id++ {
+		_, ok = Lookup(id)
+		flickr.Expect(t, ok, true)
+	}
+	license, ok = Lookup(42)
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, license.Id, photos.LicenseId(42))
+}
+
+func TestAttribution(t *testing.T) {
+	info := &photos.PhotoInfo{Id: "2733", Title: "Orford <castle>", License: "4"}
+	info.Owner.NSID = "12037949754@N01"
+	info.Owner.Username = "Bees"
+	info.Urls = []photos.PhotoUrl{{Type: "photopage", Url: "https://www.flickr.com/photos/bees/2733/"}}
+
+	a, err := NewAttribution(info)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, a.Author, "Bees")
+	flickr.Expect(t, a.String(), `“Orford <castle>” by Bees (https://www.flickr.com/photos/bees/2733/) `+
+		`is licensed under Attribution License (https://creativecommons.org/licenses/by/2.0/)`)
+	flickr.Expect(t, a.HTML(), `<a href="https://www.flickr.com/photos/bees/2733/">Orford &lt;castle&gt;</a> by Bees `+
+		`is licensed under <a href="https://creativecommons.org/licenses/by/2.0/" rel="license">Attribution License</a>`)
+
+	// real name preferred, page built from the owner when urls are missing
+	info.Owner.RealName = "Cal Henderson"
+	info.Urls = nil
+	info.Title = ""
+	info.License = "0"
+	a, err = NewAttribution(info)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, a.String(), `“Untitled” by Cal Henderson (https://www.flickr.com/photos/12037949754@N01/2733/), all rights reserved`)
+	flickr.Expect(t, a.HTML(), `<a href="https://www.flickr.com/photos/12037949754@N01/2733/">Untitled</a> by Cal Henderson, all rights reserved`)
+
+	// titles are not escaped in plain text
+	a.Title = `My "best" café`
+	flickr.Expect(t, a.String(), `“My "best" café” by Cal Henderson (https://www.flickr.com/photos/12037949754@N01/2733/), all rights reserved`)
+
+	for _, license := range []string{"", "42"} {
+		info.License = license
+		_, err = NewAttribution(info)
+		flickr.ExpectValidationError(t, err)
+	}
+}
@@ -0,0 +1,233 @@
+package photos
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// PrivacyFilter restricts listings to photos with the given visibility
+type PrivacyFilter int
+
+const (
+	NoPrivacyFilterSpecified PrivacyFilter = iota
+	PublicPhotos
+	FriendsPhotos
+	FamilyPhotos
+	FriendsAndFamilyPhotos
+	PrivatePhotos
+)
+
+// ListOptionalArgs are the filters accepted by the calling user's photostream
+// listings, zero values are ignored. Sort is only used by GetWithGeoData and
+// GetWithoutGeoData.
+type ListOptionalArgs struct {
+	MinUploadDate time.Time
+	MaxUploadDate time.Time
+	MinTakenDate  time.Time
+	MaxTakenDate  time.Time
+	PrivacyFilter PrivacyFilter
+	Media         MediaType
+	Sort          SortOrder
+	Extras        flickr.Extras
+	PerPage       int // up to 500
+	Page          int
+}
+
+type PhotoListResponse struct {
+	flickr.BasicResponse
+	Photos PhotoList `xml:"photos"`
+}
+
+// PhotoCount is the number of photos uploaded or taken in a date range
+type PhotoCount struct {
+	Count    int
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+// UnmarshalXML decodes dates either as unix timestamps or mysql datetimes
+func (c *PhotoCount) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		Count    int    `xml:"count,attr"`
+		FromDate string `xml:"fromdate,attr"`
+		ToDate   string `xml:"todate,attr"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	c.Count = raw.Count
+	c.FromDate = parseFlickrDate(raw.FromDate)
+	c.ToDate = parseFlickrDate(raw.ToDate)
+	return nil
+}
+
+// Parse a unix timestamp or a mysql datetime, returning the zero time on errors
+func parseFlickrDate(value string) time.Time {
+	if t := flickr.ParseUnixTime(value); !t.IsZero() {
+		return t
+	}
+	return flickr.ParseMySQLDatetime(value)
+}
+
+type PhotoCountsResponse struct {
+	flickr.BasicResponse
+	Counts []PhotoCount `xml:"photocounts>photocount"`
+}
+
+func (opts *ListOptionalArgs) validate() error {
+	// reuse the checks of search params sharing the same semantics, listings
+	// are always restricted to the calling user
+	params := &SearchParams{
+		UserId:        "me",
+		MinUploadDate: opts.MinUploadDate,
+		MaxUploadDate: opts.MaxUploadDate,
+		MinTakenDate:  opts.MinTakenDate,
+		MaxTakenDate:  opts.MaxTakenDate,
+		Media:         opts.Media,
+		Sort:          opts.Sort,
+		PerPage:       opts.PerPage,
+		Page:          opts.Page,
+	}
+	if opts.PrivacyFilter < NoPrivacyFilterSpecified || opts.PrivacyFilter > PrivatePhotos {
+		return flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("unknown privacy filter %d", opts.PrivacyFilter))
+	}
+	return params.Validate()
+}
+
+// Perform a signed POST request for a method listing the calling user's photos
+func listPhotos(client *flickr.FlickrClient, method string, opts *ListOptionalArgs) (*PhotoListResponse, error) {
+	if err := opts.validate(); err != nil {
+		return &PhotoListResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args = (&SearchParams{
+		MinUploadDate: opts.MinUploadDate,
+		MaxUploadDate: opts.MaxUploadDate,
+		MinTakenDate:  opts.MinTakenDate,
+		MaxTakenDate:  opts.MaxTakenDate,
+		Media:         opts.Media,
+		Sort:          opts.Sort,
+		Extras:        opts.Extras,
+		PerPage:       opts.PerPage,
+		Page:          opts.Page,
+	}).args()
+	client.Args.Set("method", method)
+	if opts.PrivacyFilter != NoPrivacyFilterSpecified {
+		client.Args.Set("privacy_filter", strconv.Itoa(int(opts.PrivacyFilter)))
+	}
+	client.OAuthSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetNotInSet returns the calling user's photos not belonging to any photoset.
+// This method requires authentication with 'read' permission.
+func GetNotInSet(client *flickr.FlickrClient, opts ListOptionalArgs) (*PhotoListResponse, error) {
+	opts.Sort = NoSortSpecified
+	return listPhotos(client, "flickr.photos.getNotInSet", &opts)
+}
+
+// GetUntagged returns the calling user's photos with no tags.
+// This method requires authentication with 'read' permission.
+func GetUntagged(client *flickr.FlickrClient, opts ListOptionalArgs) (*PhotoListResponse, error) {
+	opts.Sort = NoSortSpecified
+	return listPhotos(client, "flickr.photos.getUntagged", &opts)
+}
+
+// GetWithGeoData returns the calling user's geotagged photos.
+// This method requires authentication with 'read' permission.
+func GetWithGeoData(client *flickr.FlickrClient, opts ListOptionalArgs) (*PhotoListResponse, error) {
+	return listPhotos(client, "flickr.photos.getWithGeoData", &opts)
+}
+
+// GetWithoutGeoData returns the calling user's photos with no location.
+// This method requires authentication with 'read' permission.
+func GetWithoutGeoData(client *flickr.FlickrClient, opts ListOptionalArgs) (*PhotoListResponse, error) {
+	return listPhotos(client, "flickr.photos.getWithoutGeoData", &opts)
+}
+
+// RecentlyUpdated returns the calling user's photos created or modified since
+// minDate, sorted by last update. Request the last_update extra for incremental
+// syncs.
+// This method requires authentication with 'read' permission.
+func RecentlyUpdated(client *flickr.FlickrClient, minDate time.Time, extras flickr.Extras, perPage, page int) (*PhotoListResponse, error) {
+	if minDate.IsZero() {
+		return &PhotoListResponse{}, flickErr.NewError(flickErr.ValidationError, "min date is required")
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args = (&SearchParams{Extras: extras, PerPage: perPage, Page: page}).args()
+	client.Args.Set("method", "flickr.photos.recentlyUpdated")
+	client.Args.Set("min_date", flickr.FormatUnixTime(minDate))
+	client.OAuthSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetRecent returns the latest public photos uploaded to Flickr
+func GetRecent(client *flickr.FlickrClient, extras flickr.Extras, perPage, page int) (*PhotoListResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args = (&SearchParams{Extras: extras, PerPage: perPage, Page: page}).args()
+	client.Args.Set("method", "flickr.photos.getRecent")
+	client.OAuthSign()
+
+	response := &PhotoListResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetCounts returns how many of the calling user's photos were uploaded
+// between each consecutive pair of dates, or taken between each pair of
+// takenDates. Exactly one of the two lists must be given, with at least two dates.
+// This method requires authentication with 'read' permission.
+func GetCounts(client *flickr.FlickrClient, dates []time.Time, takenDates []time.Time) (*PhotoCountsResponse, error) {
+	if (len(dates) > 0) == (len(takenDates) > 0) {
+		return &PhotoCountsResponse{}, flickErr.NewError(flickErr.ValidationError,
+			"either dates or taken dates are required")
+	}
+	if len(dates) == 1 || len(takenDates) == 1 {
+		return &PhotoCountsResponse{}, flickErr.NewError(flickErr.ValidationError,
+			"at least two dates are required")
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.getCounts")
+	if len(dates) > 0 {
+		values := make([]string, len(dates))
+		for i, d := range dates {
+			values[i] = flickr.FormatUnixTime(d)
+		}
+		client.Args.Set("dates", strings.Join(values, ","))
+	} else {
+		values := make([]string, len(takenDates))
+		for i, d := range takenDates {
+			values[i] = flickr.FormatMySQLDatetime(d)
+		}
+		client.Args.Set("taken_dates", strings.Join(values, ","))
+	}
+	client.OAuthSign()
+
+	response := &PhotoCountsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
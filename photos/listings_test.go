@@ -0,0 +1,134 @@
+package photos
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+const photoList = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="2">
+    <photo id="2636" owner="47058503995@N01" secret="a123456" server="2" title="test_04" ispublic="1" isfriend="0" isfamily="0" lastupdate="1166047672" />
+    <photo id="2635" owner="47058503995@N01" secret="b123456" server="2" title="test_03" ispublic="0" isfriend="1" isfamily="1" lastupdate="1166047600" />
+  </photos>
+</rsp>`
+
+func TestListings(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photoList, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	opts := ListOptionalArgs{
+		MinUploadDate: time.Unix(1166000000, 0),
+		MaxTakenDate:  time.Date(2020, 5, 4, 10, 30, 0, 0, time.UTC),
+		PrivacyFilter: PrivatePhotos,
+		Media:         PhotosMedia,
+		Sort:          DateTakenDesc,
+		Extras:        flickr.Extras{flickr.ExtraLastUpdate},
+		PerPage:       100,
+	}
+	calls := map[string]func(*flickr.FlickrClient, ListOptionalArgs) (*PhotoListResponse, error){
+		"flickr.photos.getNotInSet":       GetNotInSet,
+		"flickr.photos.getUntagged":       GetUntagged,
+		"flickr.photos.getWithGeoData":    GetWithGeoData,
+		"flickr.photos.getWithoutGeoData": GetWithoutGeoData,
+	}
+	for method, call := range calls {
+		resp, err := call(fclient, opts)
+		flickr.Expect(t, err, nil)
+		flickr.Expect(t, fclient.Args.Get("method"), method)
+		flickr.Expect(t, fclient.Args.Get("min_upload_date"), "1166000000")
+		flickr.Expect(t, fclient.Args.Get("max_taken_date"), "2020-05-04 10:30:00")
+		flickr.Expect(t, fclient.Args.Get("privacy_filter"), "5")
+		flickr.Expect(t, fclient.Args.Get("media"), "photos")
+		flickr.Expect(t, fclient.Args.Get("extras"), "last_update")
+		flickr.Expect(t, len(resp.Photos.Photos), 2)
+		flickr.Expect(t, resp.Photos.Photos[1].LastUpdate.Equal(time.Unix(1166047600, 0)), true)
+	}
+
+	// sort is only accepted by geo listings
+	GetNotInSet(fclient, opts)
+	_, ok := fclient.Args["sort"]
+	flickr.Expect(t, ok, false)
+	GetWithGeoData(fclient, opts)
+	flickr.Expect(t, fclient.Args.Get("sort"), "date-taken-desc")
+
+	resp, err := GetUntagged(fclient, ListOptionalArgs{PrivacyFilter: 6})
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+	_, err = GetUntagged(fclient, ListOptionalArgs{MinTakenDate: time.Now(), MaxTakenDate: time.Unix(0, 0)})
+	flickr.ExpectValidationError(t, err)
+	_, err = GetWithoutGeoData(fclient, ListOptionalArgs{})
+	flickr.Expect(t, err, nil)
+}
+
+func TestRecentlyUpdated(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photoList, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := RecentlyUpdated(fclient, time.Unix(1166000000, 0), flickr.Extras{flickr.ExtraLastUpdate}, 0, 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.recentlyUpdated")
+	flickr.Expect(t, fclient.Args.Get("min_date"), "1166000000")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	_, ok := fclient.Args["per_page"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, resp.Photos.Total, 2)
+
+	_, err = RecentlyUpdated(fclient, time.Time{}, nil, 0, 0)
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestGetRecent(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, photoList, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetRecent(fclient, nil, 10, 0)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getRecent")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "10")
+	flickr.Expect(t, resp.Photos.Photos[0].Id, "2636")
+}
+
+func TestGetCounts(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photocounts>
+    <photocount count="4" fromdate="1093566950" todate="1093653350" />
+    <photocount count="0" fromdate="2004-08-28 00:00:00" todate="2004-08-29 00:00:00" />
+  </photocounts>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	dates := []time.Time{time.Unix(1093566950, 0), time.Unix(1093653350, 0), time.Unix(1093739750, 0)}
+	resp, err := GetCounts(fclient, dates, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.getCounts")
+	flickr.Expect(t, fclient.Args.Get("dates"), "1093566950,1093653350,1093739750")
+	flickr.Expect(t, len(resp.Counts), 2)
+	flickr.Expect(t, resp.Counts[0].Count, 4)
+	flickr.Expect(t, resp.Counts[0].FromDate.Equal(time.Unix(1093566950, 0)), true)
+	flickr.Expect(t, resp.Counts[1].ToDate, time.Date(2004, 8, 29, 0, 0, 0, 0, time.UTC))
+
+	taken := []time.Time{time.Date(2004, 8, 28, 0, 0, 0, 0, time.UTC), time.Date(2004, 8, 29, 0, 0, 0, 0, time.UTC)}
+	_, err = GetCounts(fclient, nil, taken)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("taken_dates"), "2004-08-28 00:00:00,2004-08-29 00:00:00")
+
+	_, err = GetCounts(fclient, dates, taken)
+	flickr.ExpectValidationError(t, err)
+	_, err = GetCounts(fclient, nil, nil)
+	flickr.ExpectValidationError(t, err)
+	counts, err := GetCounts(fclient, dates[:1], nil)
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, counts != nil, true)
+}
@@ -0,0 +1,175 @@
+package photos
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// Label of the size notes coordinates refer to
+const noteFrameLabel = "Medium"
+
+type AddNoteResponse struct {
+	flickr.BasicResponse
+	Note struct {
+		ID string `xml:"id,attr"`
+	} `xml:"note"`
+}
+
+func (n Note) validate() error {
+	if n.X < 0 || n.Y < 0 || n.W <= 0 || n.H <= 0 {
+		return flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("invalid note rectangle %d,%d %dx%d", n.X, n.Y, n.W, n.H))
+	}
+	if n.Text == "" {
+		return flickErr.NewError(flickErr.ValidationError, "note text is required")
+	}
+	return nil
+}
+
+func setNoteArgs(client *flickr.FlickrClient, n Note) {
+	client.Args.Set("note_x", strconv.Itoa(n.X))
+	client.Args.Set("note_y", strconv.Itoa(n.Y))
+	client.Args.Set("note_w", strconv.Itoa(n.W))
+	client.Args.Set("note_h", strconv.Itoa(n.H))
+	client.Args.Set("note_text", n.Text)
+}
+
+// AddNote adds a note to a photo, coordinates must be relative to the 500px
+// version of the photo, see NoteToFrame. The ID and author fields of note are
+// ignored.
+// This method requires authentication with 'write' permission.
+func AddNote(client *flickr.FlickrClient, photoId string, note Note) (*AddNoteResponse, error) {
+	if err := note.validate(); err != nil {
+		return &AddNoteResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.notes.add")
+	client.Args.Set("photo_id", photoId)
+	setNoteArgs(client, note)
+	client.OAuthSign()
+
+	response := &AddNoteResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// EditNote changes rectangle and text of the note identified by note.ID.
+// This method requires authentication with 'write' permission.
+func EditNote(client *flickr.FlickrClient, note Note) (*flickr.BasicResponse, error) {
+	if err := note.validate(); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.notes.edit")
+	client.Args.Set("note_id", note.ID)
+	setNoteArgs(client, note)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// DeleteNote deletes a note from a photo.
+// This method requires authentication with 'write' permission.
+func DeleteNote(client *flickr.FlickrClient, noteId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.notes.delete")
+	client.Args.Set("note_id", noteId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// Return width and height of the size with the given label
+func (info *PhotoAccessInfo) dimensions(label string) (int, int, error) {
+	for _, s := range info.Sizes {
+		if s.Label != label {
+			continue
+		}
+		w, errW := strconv.Atoi(s.Width)
+		h, errH := strconv.Atoi(s.Height)
+		if errW != nil || errH != nil || w <= 0 || h <= 0 {
+			return 0, 0, flickErr.NewError(flickErr.ValidationError,
+				fmt.Sprintf("invalid dimensions %sx%s for size %q", s.Width, s.Height, label))
+		}
+		return w, h, nil
+	}
+	return 0, 0, flickErr.NewError(flickErr.ValidationError, fmt.Sprintf("size %q not available", label))
+}
+
+// Return the dimensions notes coordinates refer to: the 500px version of the
+// photo or, for smaller photos, the original
+func (info *PhotoAccessInfo) noteFrame() (int, int, error) {
+	w, h, err := info.dimensions(noteFrameLabel)
+	if err == nil {
+		return w, h, nil
+	}
+	// smaller photos have no medium size, the largest one is the original
+	var maxW, maxH int
+	for _, s := range info.Sizes {
+		sw, _ := strconv.Atoi(s.Width)
+		sh, _ := strconv.Atoi(s.Height)
+		if sw > maxW && sh > 0 {
+			maxW, maxH = sw, sh
+		}
+	}
+	if maxW == 0 {
+		return 0, 0, err
+	}
+	return maxW, maxH, nil
+}
+
+// Scale the note rectangle from a width x height frame to another
+func scaleNote(n Note, fromW, fromH, toW, toH int) Note {
+	sx := float64(toW) / float64(fromW)
+	sy := float64(toH) / float64(fromH)
+	n.X = int(math.Round(float64(n.X) * sx))
+	n.Y = int(math.Round(float64(n.Y) * sy))
+	n.W = int(math.Round(float64(n.W) * sx))
+	n.H = int(math.Round(float64(n.H) * sy))
+	return n
+}
+
+// NoteToSize converts the rectangle of a note, as returned by GetInfo, to the
+// coordinates of the size with the given label (ex. "Large") in sizes, as
+// returned by GetSizes
+func NoteToSize(note Note, sizes *PhotoAccessInfo, label string) (Note, error) {
+	frameW, frameH, err := sizes.noteFrame()
+	if err != nil {
+		return note, err
+	}
+	w, h, err := sizes.dimensions(label)
+	if err != nil {
+		return note, err
+	}
+	return scaleNote(note, frameW, frameH, w, h), nil
+}
+
+// NoteToFrame converts a rectangle drawn on the size with the given label in
+// sizes to the 500px reference frame expected by AddNote and EditNote
+func NoteToFrame(note Note, sizes *PhotoAccessInfo, label string) (Note, error) {
+	frameW, frameH, err := sizes.noteFrame()
+	if err != nil {
+		return note, err
+	}
+	w, h, err := sizes.dimensions(label)
+	if err != nil {
+		return note, err
+	}
+	return scaleNote(note, w, h, frameW, frameH), nil
+}
@@ -0,0 +1,86 @@
+package photos
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+var sampleSizes = &PhotoAccessInfo{Sizes: []PhotoDownloadInfo{
+	{Label: "Square", Width: "75", Height: "75"},
+	{Label: "Medium", Width: "500", Height: "375"},
+	{Label: "Large", Width: "1024", Height: "768"},
+	{Label: "Original", Width: "4000", Height: "3000"},
+}}
+
+func TestAddNote(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><note id="1234" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := AddNote(fclient, "123", Note{X: 10, Y: 20, W: 50, H: 40, Text: "Nikki"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Note.ID, "1234")
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.notes.add")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "123")
+	flickr.Expect(t, fclient.Args.Get("note_x"), "10")
+	flickr.Expect(t, fclient.Args.Get("note_h"), "40")
+	flickr.Expect(t, fclient.Args.Get("note_text"), "Nikki")
+
+	_, err = AddNote(fclient, "123", Note{X: 10, Y: 20, W: 0, H: 40, Text: "Nikki"})
+	flickr.ExpectValidationError(t, err)
+	resp, err = AddNote(fclient, "123", Note{X: 10, Y: 20, W: 5, H: 40})
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, resp != nil, true)
+}
+
+func TestEditDeleteNote(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := EditNote(fclient, Note{ID: "1234", X: 1, Y: 2, W: 3, H: 4, Text: "moved"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.notes.edit")
+	flickr.Expect(t, fclient.Args.Get("note_id"), "1234")
+	flickr.Expect(t, fclient.Args.Get("note_w"), "3")
+
+	_, err = DeleteNote(fclient, "1234")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.notes.delete")
+	flickr.Expect(t, fclient.Args.Get("note_id"), "1234")
+}
+
+func TestNoteConversion(t *testing.T) {
+	note := Note{ID: "1", X: 100, Y: 50, W: 200, H: 100, Text: "foo"}
+
+	large, err := NoteToSize(note, sampleSizes, "Large")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, large, Note{ID: "1", X: 205, Y: 102, W: 410, H: 205, Text: "foo"})
+
+	orig, err := NoteToSize(note, sampleSizes, "Original")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, orig.X, 800)
+	flickr.Expect(t, orig.H, 800)
+
+	back, err := NoteToFrame(orig, sampleSizes, "Original")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, back, note)
+
+	_, err = NoteToSize(note, sampleSizes, "Huge")
+	flickr.Expect(t, err != nil, true)
+
+	// photos smaller than 500px use the original as reference frame
+	small := &PhotoAccessInfo{Sizes: []PhotoDownloadInfo{
+		{Label: "Square", Width: "75", Height: "75"},
+		{Label: "Original", Width: "400", Height: "300"},
+	}}
+	same, err := NoteToSize(note, small, "Original")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, same, note)
+
+	_, err = NoteToSize(note, &PhotoAccessInfo{}, "Original")
+	flickr.Expect(t, err != nil, true)
+}
@@ -0,0 +1,155 @@
+package photos
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// PersonRect is the area of a photo where a person appears, in pixels
+// relative to the photo_width and photo_height of GetPeopleList
+type PersonRect struct {
+	X int `xml:"x,attr"`
+	Y int `xml:"y,attr"`
+	W int `xml:"w,attr"`
+	H int `xml:"h,attr"`
+}
+
+// Person is a user tagged in a photo
+type Person struct {
+	NSID       string `xml:"nsid,attr"`
+	Username   string `xml:"username,attr"`
+	RealName   string `xml:"realname,attr"`
+	PathAlias  string `xml:"path_alias,attr"`
+	IconServer string `xml:"iconserver,attr"`
+	IconFarm   string `xml:"iconfarm,attr"`
+	// NSID of the user who tagged the person
+	AddedBy string `xml:"added_by,attr"`
+	// Zero when the person was tagged without coordinates, see HasRect
+	PersonRect
+}
+
+// HasRect tells whether the person was tagged on a specific area of the photo
+func (p *Person) HasRect() bool {
+	return p.W > 0 && p.H > 0
+}
+
+type PeopleListResponse struct {
+	flickr.BasicResponse
+	People struct {
+		Total       int      `xml:"total,attr"`
+		PhotoWidth  int      `xml:"photo_width,attr"`
+		PhotoHeight int      `xml:"photo_height,attr"`
+		Persons     []Person `xml:"person"`
+	} `xml:"people"`
+}
+
+func (r PersonRect) validate() error {
+	if r.X < 0 || r.Y < 0 || r.W <= 0 || r.H <= 0 {
+		return flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("invalid person rectangle %d,%d %dx%d", r.X, r.Y, r.W, r.H))
+	}
+	return nil
+}
+
+func setPersonArgs(client *flickr.FlickrClient, r PersonRect) {
+	client.Args.Set("person_x", strconv.Itoa(r.X))
+	client.Args.Set("person_y", strconv.Itoa(r.Y))
+	client.Args.Set("person_w", strconv.Itoa(r.W))
+	client.Args.Set("person_h", strconv.Itoa(r.H))
+}
+
+// AddPerson tags a user in a photo, rect is optional and may be nil.
+// This method requires authentication with 'write' permission.
+func AddPerson(client *flickr.FlickrClient, photoId string, userId string, rect *PersonRect) (*flickr.BasicResponse, error) {
+	if rect != nil {
+		if err := rect.validate(); err != nil {
+			return &flickr.BasicResponse{}, err
+		}
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.add")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	if rect != nil {
+		setPersonArgs(client, *rect)
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// DeletePerson removes a user from the people tagged in a photo.
+// This method requires authentication with 'write' permission.
+func DeletePerson(client *flickr.FlickrClient, photoId string, userId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.delete")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// DeletePersonCoords removes the rectangle of a person tagged in a photo,
+// leaving the person tagged.
+// This method requires authentication with 'write' permission.
+func DeletePersonCoords(client *flickr.FlickrClient, photoId string, userId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.deleteCoords")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// EditPersonCoords changes the rectangle of a person tagged in a photo.
+// This method requires authentication with 'write' permission.
+func EditPersonCoords(client *flickr.FlickrClient, photoId string, userId string, rect PersonRect) (*flickr.BasicResponse, error) {
+	if err := rect.validate(); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.editCoords")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("user_id", userId)
+	setPersonArgs(client, rect)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetPeopleList returns the people tagged in a photo
+func GetPeopleList(client *flickr.FlickrClient, photoId string) (*PeopleListResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.people.getList")
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &PeopleListResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
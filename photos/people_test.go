@@ -0,0 +1,81 @@
+package photos
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func TestGetPeopleList(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <people total="2" photo_width="1024" photo_height="768">
+    <person nsid="87944415@N00" username="hitherto" iconserver="1" iconfarm="1" realname="Simon Batistoni" added_by="12037949754@N01" x="50" y="60" w="100" h="120" />
+    <person nsid="12037949754@N01" username="Bees" iconserver="1" iconfarm="1" realname="Cal Henderson" added_by="12037949754@N01" />
+  </people>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPeopleList(fclient, "123")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.people.getList")
+	flickr.Expect(t, resp.People.Total, 2)
+	flickr.Expect(t, resp.People.PhotoWidth, 1024)
+	flickr.Expect(t, resp.People.PhotoHeight, 768)
+	p := resp.People.Persons[0]
+	flickr.Expect(t, p.NSID, "87944415@N00")
+	flickr.Expect(t, p.RealName, "Simon Batistoni")
+	flickr.Expect(t, p.AddedBy, "12037949754@N01")
+	flickr.Expect(t, p.HasRect(), true)
+	flickr.Expect(t, p.PersonRect, PersonRect{X: 50, Y: 60, W: 100, H: 120})
+	flickr.Expect(t, resp.People.Persons[1].HasRect(), false)
+}
+
+func TestAddPerson(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := AddPerson(fclient, "123", "87944415@N00", nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.people.add")
+	flickr.Expect(t, fclient.Args.Get("user_id"), "87944415@N00")
+	_, ok := fclient.Args["person_x"]
+	flickr.Expect(t, ok, false)
+
+	_, err = AddPerson(fclient, "123", "87944415@N00", &PersonRect{X: 1, Y: 2, W: 30, H: 40})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("person_x"), "1")
+	flickr.Expect(t, fclient.Args.Get("person_h"), "40")
+
+	resp, err := AddPerson(fclient, "123", "87944415@N00", &PersonRect{X: -1, W: 30, H: 40})
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+}
+
+func TestEditDeletePerson(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	_, err := EditPersonCoords(fclient, "123", "87944415@N00", PersonRect{X: 5, Y: 5, W: 10, H: 10})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.people.editCoords")
+	flickr.Expect(t, fclient.Args.Get("person_w"), "10")
+
+	_, err = EditPersonCoords(fclient, "123", "87944415@N00", PersonRect{})
+	flickr.Expect(t, err != nil, true)
+
+	_, err = DeletePersonCoords(fclient, "123", "87944415@N00")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.people.deleteCoords")
+
+	_, err = DeletePerson(fclient, "123", "87944415@N00")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.people.delete")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "123")
+}
@@ -1,20 +1,23 @@
 package photos
 
 import (
+	"encoding/xml"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
 )
 
 type PhotoInfo struct {
-	Id           string `xml:"id,attr"`
-	Secret       string `xml:"secret,attr"`
-	Server       string `xml:"server,attr"`
-	Farm         string `xml:"farm,attr"`
-	DateUploaded string `xml:"dateuploaded,attr"`
-	IsFavorite   bool   `xml:"isfavorite,attr"`
-	License      string `xml:"license,attr"`
+	Id           string    `xml:"id,attr"`
+	Secret       string    `xml:"secret,attr"`
+	Server       string    `xml:"server,attr"`
+	Farm         string    `xml:"farm,attr"`
+	DateUploaded time.Time `xml:"-"`
+	IsFavorite   bool      `xml:"isfavorite,attr"`
+	License      string    `xml:"license,attr"`
 	// NOTE: one less than safety level set on upload (ie, here 0 = safe, 1 = moderate, 2 = restricted)
 	//       while on upload, 1 = safe, 2 = moderate, 3 = restricted
 	SafetyLevel    int    `xml:"safety_level,attr"`
@@ -23,6 +26,7 @@ type PhotoInfo struct {
 	OriginalFormat string `xml:"originalformat,attr"`
 	Views          int    `xml:"views,attr"`
 	Media          string `xml:"media,attr"`
+	Owner          Owner  `xml:"owner"`
 	Title          string `xml:"title"`
 	Description    string `xml:"description"`
 	Visibility     struct {
@@ -30,16 +34,10 @@ type PhotoInfo struct {
 		IsFriend bool `xml:"isfriend,attr"`
 		IsFamily bool `xml:"isfamily,attr"`
 	} `xml:"visibility"`
-	Dates struct {
-		Posted           string `xml:"posted,attr"`
-		Taken            string `xml:"taken,attr"`
-		TakenGranularity string `xml:"takengranularity,attr"`
-		TakenUnknown     string `xml:"takenunknown,attr"`
-		LastUpdate       string `xml:"lastupdate,attr"`
-	} `xml:"dates"`
+	Dates       PhotoDates `xml:"dates"`
 	Permissions struct {
 		PermComment string `xml:"permcomment,attr"`
-		PermAdMeta  string `xml:"permadmeta,attr"`
+		PermAdMeta  string `xml:"permaddmeta,attr"`
 	} `xml:"permissions"`
 	Editability struct {
 		CanComment string `xml:"cancomment,attr"`
@@ -55,16 +53,112 @@ type PhotoInfo struct {
 		CanPrint    string `xml:"canprint,attr"`
 		CanShare    string `xml:"canshare,attr"`
 	} `xml:"usage"`
-	Comments int   `xml:"comments"`
-	Tags     []Tag `xml:"tags>tag"`
-	// Notes XXX: not handled yet
-	// People XXX: not handled yet
-	// Urls XXX: not handled yet
+	Comments int    `xml:"comments"`
+	Notes    []Note `xml:"notes>note"`
+	People   struct {
+		HasPeople bool `xml:"haspeople,attr"`
+	} `xml:"people"`
+	Tags []Tag `xml:"tags>tag"`
+	// Only present for geotagged photos
+	Location *flickr.Location `xml:"location"`
+	GeoPerms *GeoPerms        `xml:"geoperms"`
+	Urls     []PhotoUrl       `xml:"urls>url"`
 }
+
+// UnmarshalXML decodes the unix timestamp in dateuploaded into DateUploaded
+func (p *PhotoInfo) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type photoInfo PhotoInfo
+	raw := struct {
+		*photoInfo
+		DateUploaded string `xml:"dateuploaded,attr"`
+	}{photoInfo: (*photoInfo)(p)}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	p.DateUploaded = flickr.ParseUnixTime(raw.DateUploaded)
+	return nil
+}
+
+// PhotoDates are the dates of a photo, posted is the same as DateUploaded
+// unless changed with SetDates
+type PhotoDates struct {
+	Posted     time.Time
+	Taken      flickr.TakenDate
+	LastUpdate time.Time
+}
+
+func (dates *PhotoDates) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		Posted           string `xml:"posted,attr"`
+		Taken            string `xml:"taken,attr"`
+		TakenGranularity string `xml:"takengranularity,attr"`
+		TakenUnknown     string `xml:"takenunknown,attr"`
+		LastUpdate       string `xml:"lastupdate,attr"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	dates.Posted = flickr.ParseUnixTime(raw.Posted)
+	dates.Taken = flickr.ParseTakenDate(raw.Taken, raw.TakenGranularity, raw.TakenUnknown)
+	dates.LastUpdate = flickr.ParseUnixTime(raw.LastUpdate)
+	return nil
+}
+
+type Owner struct {
+	NSID       string `xml:"nsid,attr"`
+	Username   string `xml:"username,attr"`
+	RealName   string `xml:"realname,attr"`
+	Location   string `xml:"location,attr"`
+	IconServer string `xml:"iconserver,attr"`
+	IconFarm   string `xml:"iconfarm,attr"`
+	PathAlias  string `xml:"path_alias,attr"`
+}
+
+// Note is a rectangle annotated with some text, coordinates are relative
+// to the 500px version of the photo
+type Note struct {
+	ID         string `xml:"id,attr"`
+	Author     string `xml:"author,attr"`
+	AuthorName string `xml:"authorname,attr"`
+	X          int    `xml:"x,attr"`
+	Y          int    `xml:"y,attr"`
+	W          int    `xml:"w,attr"`
+	H          int    `xml:"h,attr"`
+	Text       string `xml:",chardata"`
+}
+
+// GeoPerms tells who can see the location of a photo
+type GeoPerms struct {
+	Id        string `xml:"id,attr"`
+	IsPublic  bool   `xml:"ispublic,attr"`
+	IsContact bool   `xml:"iscontact,attr"`
+	IsFriend  bool   `xml:"isfriend,attr"`
+	IsFamily  bool   `xml:"isfamily,attr"`
+}
+
+type PhotoUrl struct {
+	// Only "photopage" so far
+	Type string `xml:"type,attr"`
+	Url  string `xml:",chardata"`
+}
+
 type Tag struct {
-	ID    string `xml:"id,attr"`
-	Raw   string `xml:"raw,attr"`
-	Value string `xml:",chardata"`
+	ID         string `xml:"id,attr"`
+	Author     string `xml:"author,attr"`
+	AuthorName string `xml:"authorname,attr"`
+	Raw        string `xml:"raw,attr"`
+	MachineTag bool   `xml:"machine_tag,attr"`
+	Value      string `xml:",chardata"`
+}
+
+// PhotoPage returns the URL of the photo page on Flickr, or "" if missing
+func (p *PhotoInfo) PhotoPage() string {
+	for _, u := range p.Urls {
+		if u.Type == "photopage" {
+			return u.Url
+		}
+	}
+	return ""
 }
 
 type PhotoInfoResponse struct {
@@ -157,19 +251,25 @@ func GetInfo(client *flickr.FlickrClient, id string, secret string) (*PhotoInfoR
 	return response, err
 }
 
-// Set date posted and date taken on a Flickr photo
-// datePosted and dateTaken are optional and may be set to ""
-func SetDates(client *flickr.FlickrClient, id string, datePosted string, dateTaken string) (*flickr.BasicResponse, error) {
+// Set date posted and date taken on a Flickr photo, zero values are ignored
+// but at least one of them is required. Only the fields of dateTaken down to
+// its granularity are kept by Flickr, Unknown is ignored.
+func SetDates(client *flickr.FlickrClient, id string, datePosted time.Time, dateTaken flickr.TakenDate) (*flickr.BasicResponse, error) {
+	if datePosted.IsZero() && dateTaken.IsZero() {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError, "either date posted or date taken is required")
+	}
+
 	client.Init()
 	client.EndpointUrl = flickr.API_ENDPOINT
 	client.HTTPVerb = "POST"
 	client.Args.Set("method", "flickr.photos.setDates")
 	client.Args.Set("photo_id", id)
-	if datePosted != "" {
-		client.Args.Set("date_posted", datePosted)
+	if !datePosted.IsZero() {
+		client.Args.Set("date_posted", flickr.FormatUnixTime(datePosted))
 	}
-	if dateTaken != "" {
-		client.Args.Set("date_taken", dateTaken)
+	if !dateTaken.IsZero() {
+		client.Args.Set("date_taken", flickr.FormatMySQLDatetime(dateTaken.Time))
+		client.Args.Set("date_taken_granularity", strconv.Itoa(int(dateTaken.Granularity)))
 	}
 	client.OAuthSign()
 
@@ -2,6 +2,7 @@ package photos
 
 import (
 	"testing"
+	"time"
 
 	"gopkg.in/masci/flickr.v3"
 	flickErr "gopkg.in/masci/flickr.v3/error"
@@ -89,3 +90,96 @@ func TestGetTags(t *testing.T) {
 	}
 	flickr.Expect(t, resp.HasErrors(), false)
 }
+
+func TestGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photo id="2733" secret="123456" server="12" farm="1" dateuploaded="1166047672" isfavorite="0" license="3" safety_level="0" rotation="90" views="12" media="photo">
+    <owner nsid="12037949754@N01" username="Bees" realname="Cal Henderson" location="Bedford, UK" iconserver="1" iconfarm="1" path_alias="bees" />
+    <title>orford_castle_taster</title>
+    <description>hello!</description>
+    <visibility ispublic="1" isfriend="0" isfamily="0" />
+    <dates posted="1166047672" taken="2006-12-01 00:00:00" takengranularity="4" takenunknown="0" lastupdate="1166047700" />
+    <permissions permcomment="3" permaddmeta="2" />
+    <comments>1</comments>
+    <notes>
+      <note id="313" author="12037949754@N01" authorname="Bees" x="10" y="10" w="50" h="50">foo</note>
+    </notes>
+    <people haspeople="1" />
+    <tags>
+      <tag id="1234" author="12037949754@N01" authorname="Bees" raw="woo yay" machine_tag="0">wooyay</tag>
+      <tag id="1235" author="12037949754@N01" authorname="Bees" raw="geo:lat=52.09" machine_tag="1">geo:lat=5209</tag>
+    </tags>
+    <location latitude="52.09" longitude="1.53" accuracy="16" context="2">
+      <locality place_id="abc" woeid="26330">Orford</locality>
+      <country place_id="def" woeid="23424975">United Kingdom</country>
+    </location>
+    <geoperms ispublic="1" iscontact="0" isfriend="0" isfamily="0" />
+    <urls>
+      <url type="photopage">https://www.flickr.com/photos/bees/2733/</url>
+    </urls>
+  </photo>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetInfo(fclient, "2733", "")
+	flickr.Expect(t, err, nil)
+	p := resp.Photo
+	flickr.Expect(t, p.DateUploaded.Equal(time.Unix(1166047672, 0)), true)
+	flickr.Expect(t, p.Dates.Posted.Equal(time.Unix(1166047672, 0)), true)
+	flickr.Expect(t, p.Dates.LastUpdate.Equal(time.Unix(1166047700, 0)), true)
+	flickr.Expect(t, p.Dates.Taken, flickr.TakenDate{Time: time.Date(2006, 12, 1, 0, 0, 0, 0, time.UTC), Granularity: flickr.TakenMonth})
+	flickr.Expect(t, p.Dates.Taken.String(), "December 2006")
+	flickr.Expect(t, p.Owner.NSID, "12037949754@N01")
+	flickr.Expect(t, p.Owner.RealName, "Cal Henderson")
+	flickr.Expect(t, p.Owner.PathAlias, "bees")
+	flickr.Expect(t, p.Permissions.PermAdMeta, "2")
+	flickr.Expect(t, len(p.Notes), 1)
+	flickr.Expect(t, p.Notes[0], Note{ID: "313", Author: "12037949754@N01", AuthorName: "Bees", X: 10, Y: 10, W: 50, H: 50, Text: "foo"})
+	flickr.Expect(t, p.People.HasPeople, true)
+	flickr.Expect(t, p.Tags[0].Author, "12037949754@N01")
+	flickr.Expect(t, p.Tags[0].MachineTag, false)
+	flickr.Expect(t, p.Tags[1].MachineTag, true)
+	flickr.Expect(t, p.Location.Accuracy, flickr.StreetLevel)
+	flickr.Expect(t, p.Location.Locality.Name, "Orford")
+	flickr.Expect(t, p.Location.Country.WoeId, "23424975")
+	flickr.Expect(t, p.GeoPerms.IsPublic, true)
+	flickr.Expect(t, p.PhotoPage(), "https://www.flickr.com/photos/bees/2733/")
+
+	// not geotagged
+	server, client = flickr.FlickrMock(200, photoInfo, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	resp, err = GetInfo(fclient, "52435165562", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photo.Location == nil, true)
+	flickr.Expect(t, resp.Photo.GeoPerms == nil, true)
+	flickr.Expect(t, resp.Photo.People.HasPeople, false)
+	flickr.Expect(t, len(resp.Photo.Notes), 0)
+	flickr.Expect(t, resp.Photo.Owner.Username, "pankaj.anand")
+}
+
+func TestSetDates(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	taken := flickr.TakenDate{Time: time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), Granularity: flickr.TakenCirca}
+	_, err := SetDates(fclient, "123", time.Unix(1166047672, 0), taken)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setDates")
+	flickr.Expect(t, fclient.Args.Get("date_posted"), "1166047672")
+	flickr.Expect(t, fclient.Args.Get("date_taken"), "1980-01-01 00:00:00")
+	flickr.Expect(t, fclient.Args.Get("date_taken_granularity"), "8")
+
+	_, err = SetDates(fclient, "123", time.Time{}, taken)
+	flickr.Expect(t, err, nil)
+	_, ok := fclient.Args["date_posted"]
+	flickr.Expect(t, ok, false)
+
+	resp, err := SetDates(fclient, "123", time.Time{}, flickr.TakenDate{})
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+}
@@ -0,0 +1,398 @@
+package photos
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// Photo is an item of the photo lists returned by search and listing methods,
+// see flickr.Photo
+type Photo = flickr.Photo
+
+// PhotoList is a page of photos
+type PhotoList struct {
+	Page    int     `xml:"page,attr"`
+	Pages   int     `xml:"pages,attr"`
+	PerPage int     `xml:"perpage,attr"`
+	Total   int     `xml:"total,attr"`
+	Photos  []Photo `xml:"photo"`
+}
+
+type SearchResponse struct {
+	flickr.BasicResponse
+	Photos PhotoList `xml:"photos"`
+}
+
+// Whether results must match any or all of the given tags
+type TagMode string
+
+const (
+	NoTagModeSpecified TagMode = ""
+	TagModeAny         TagMode = "any"
+	TagModeAll         TagMode = "all"
+)
+
+type SortOrder string
+
+const (
+	NoSortSpecified     SortOrder = ""
+	DatePostedAsc       SortOrder = "date-posted-asc"
+	DatePostedDesc      SortOrder = "date-posted-desc"
+	DateTakenAsc        SortOrder = "date-taken-asc"
+	DateTakenDesc       SortOrder = "date-taken-desc"
+	InterestingnessAsc  SortOrder = "interestingness-asc"
+	InterestingnessDesc SortOrder = "interestingness-desc"
+	Relevance           SortOrder = "relevance"
+)
+
+type MediaType string
+
+const (
+	NoMediaSpecified MediaType = ""
+	AllMedia         MediaType = "all"
+	PhotosMedia      MediaType = "photos"
+	VideosMedia      MediaType = "videos"
+)
+
+type SafetyLevel int
+
+const (
+	NoSafetySpecified SafetyLevel = iota
+	Safe
+	Moderate
+	Restricted
+)
+
+type ContentType int
+
+const (
+	NoContentTypeSpecified ContentType = iota
+	PhotosOnly
+	ScreenShotsOnly
+	OtherOnly
+	PhotosAndScreenshots
+	ScreenShotsAndOther
+	PhotosAndOther
+	AllContentTypes
+)
+
+type RadiusUnits string
+
+const (
+	Kilometers RadiusUnits = "km"
+	Miles      RadiusUnits = "mi"
+)
+
+// Maximum search radius allowed by Flickr
+const (
+	maxRadiusKm = 32
+	maxRadiusMi = 20
+	maxPerPage  = 500
+)
+
+// BoundingBox limits a search to an area, coordinates are in decimal degrees
+type BoundingBox struct {
+	MinLongitude, MinLatitude float64
+	MaxLongitude, MaxLatitude float64
+}
+
+// GeoPoint is the center of a radial search
+type GeoPoint struct {
+	Latitude, Longitude float64
+}
+
+// SearchParams describes a flickr.photos.search query, zero values are not
+// sent to Flickr
+type SearchParams struct {
+	// Free text matching title, description and tags
+	Text           string
+	Tags           []string
+	TagMode        TagMode
+	MachineTags    []string
+	MachineTagMode TagMode
+	// User owning the photos, "me" for the calling user
+	UserId        string
+	GroupId       string
+	MinUploadDate time.Time
+	MaxUploadDate time.Time
+	MinTakenDate  time.Time
+	MaxTakenDate  time.Time
+	// Either BBox or Point can be set
+	BBox        *BoundingBox
+	Point       *GeoPoint
+	Radius      float64     // distance from Point, up to 32 km or 20 miles
+	RadiusUnits RadiusUnits // defaults to Kilometers
+	// License IDs as in flickr.photos.licenses.getInfo
+	Licenses    []string
+	Media       MediaType
+	ContentType ContentType
+	SafeSearch  SafetyLevel
+	Sort        SortOrder
+	Extras      flickr.Extras
+	PerPage     int // up to 500, 0 to use the Flickr default
+	Page        int // 0 for the first page
+}
+
+// Validate checks ranges and conflicting parameters, returning an error describing
+// every problem found
+func (p *SearchParams) Validate() error {
+	var problems []string
+	add := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	if p.Text == "" && len(p.Tags) == 0 && len(p.MachineTags) == 0 && p.UserId == "" && p.GroupId == "" &&
+		p.MinUploadDate.IsZero() && p.MaxUploadDate.IsZero() && p.MinTakenDate.IsZero() && p.MaxTakenDate.IsZero() &&
+		p.BBox == nil && p.Point == nil {
+		add("at least one of text, tags, machine tags, user, group, dates or location is required")
+	}
+	if p.TagMode != NoTagModeSpecified && len(p.Tags) == 0 {
+		add("tag mode requires tags")
+	}
+	if p.MachineTagMode != NoTagModeSpecified && len(p.MachineTags) == 0 {
+		add("machine tag mode requires machine tags")
+	}
+	for _, mode := range []TagMode{p.TagMode, p.MachineTagMode} {
+		if mode != NoTagModeSpecified && mode != TagModeAny && mode != TagModeAll {
+			add("unknown tag mode %q", mode)
+		}
+	}
+	if !p.MinUploadDate.IsZero() && !p.MaxUploadDate.IsZero() && p.MinUploadDate.After(p.MaxUploadDate) {
+		add("min upload date is after max upload date")
+	}
+	if !p.MinTakenDate.IsZero() && !p.MaxTakenDate.IsZero() && p.MinTakenDate.After(p.MaxTakenDate) {
+		add("min taken date is after max taken date")
+	}
+
+	if p.BBox != nil && p.Point != nil {
+		add("bounding box and point can't be used together")
+	}
+	if p.BBox != nil {
+		b := p.BBox
+		if !flickr.ValidCoordinates(b.MinLatitude, b.MinLongitude) || !flickr.ValidCoordinates(b.MaxLatitude, b.MaxLongitude) {
+			add("bounding box is out of range")
+		}
+		if b.MinLongitude > b.MaxLongitude || b.MinLatitude > b.MaxLatitude {
+			add("bounding box minimum coordinates must be lower than maximum ones")
+		}
+	}
+	if p.Point != nil && !flickr.ValidCoordinates(p.Point.Latitude, p.Point.Longitude) {
+		add("point is out of range")
+	}
+	if p.Radius != 0 || p.RadiusUnits != "" {
+		if p.Point == nil {
+			add("radius requires a point")
+		}
+		switch p.RadiusUnits {
+		case "", Kilometers:
+			if p.Radius < 0 || p.Radius > maxRadiusKm {
+				add("radius must be between 0 and %d km", maxRadiusKm)
+			}
+		case Miles:
+			if p.Radius < 0 || p.Radius > maxRadiusMi {
+				add("radius must be between 0 and %d mi", maxRadiusMi)
+			}
+		default:
+			add("unknown radius units %q", p.RadiusUnits)
+		}
+	}
+
+	switch p.Media {
+	case NoMediaSpecified, AllMedia, PhotosMedia, VideosMedia:
+	default:
+		add("unknown media %q", p.Media)
+	}
+	if p.ContentType < NoContentTypeSpecified || p.ContentType > AllContentTypes {
+		add("unknown content type %d", p.ContentType)
+	}
+	if p.SafeSearch < NoSafetySpecified || p.SafeSearch > Restricted {
+		add("unknown safe search level %d", p.SafeSearch)
+	}
+	switch p.Sort {
+	case NoSortSpecified, DatePostedAsc, DatePostedDesc, DateTakenAsc, DateTakenDesc,
+		InterestingnessAsc, InterestingnessDesc, Relevance:
+	default:
+		add("unknown sort order %q", p.Sort)
+	}
+	if p.PerPage < 0 || p.PerPage > maxPerPage {
+		add("per page must be between 1 and %d", maxPerPage)
+	}
+	if p.Page < 0 {
+		add("page must be positive")
+	}
+
+	if len(problems) > 0 {
+		return flickErr.NewError(flickErr.ValidationError, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// SetDateArgs sets the arguments restricting photo lists to the ones uploaded
+// or taken in a range, zero dates are not set. Upload dates are sent as unix
+// timestamps while taken dates are sent as mysql datetimes.
+func SetDateArgs(args url.Values, minUploadDate, maxUploadDate, minTakenDate, maxTakenDate time.Time) {
+	if !minUploadDate.IsZero() {
+		args.Set("min_upload_date", flickr.FormatUnixTime(minUploadDate))
+	}
+	if !maxUploadDate.IsZero() {
+		args.Set("max_upload_date", flickr.FormatUnixTime(maxUploadDate))
+	}
+	if !minTakenDate.IsZero() {
+		args.Set("min_taken_date", flickr.FormatMySQLDatetime(minTakenDate))
+	}
+	if !maxTakenDate.IsZero() {
+		args.Set("max_taken_date", flickr.FormatMySQLDatetime(maxTakenDate))
+	}
+}
+
+// Encode the parameters as API arguments
+func (p *SearchParams) args() url.Values {
+	args := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			args.Set(key, value)
+		}
+	}
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	set("text", p.Text)
+	set("tags", strings.Join(p.Tags, ","))
+	set("tag_mode", string(p.TagMode))
+	set("machine_tags", strings.Join(p.MachineTags, ","))
+	set("machine_tag_mode", string(p.MachineTagMode))
+	set("user_id", p.UserId)
+	set("group_id", p.GroupId)
+	SetDateArgs(args, p.MinUploadDate, p.MaxUploadDate, p.MinTakenDate, p.MaxTakenDate)
+	if p.BBox != nil {
+		set("bbox", strings.Join([]string{
+			formatFloat(p.BBox.MinLongitude), formatFloat(p.BBox.MinLatitude),
+			formatFloat(p.BBox.MaxLongitude), formatFloat(p.BBox.MaxLatitude),
+		}, ","))
+	}
+	if p.Point != nil {
+		set("lat", formatFloat(p.Point.Latitude))
+		set("lon", formatFloat(p.Point.Longitude))
+	}
+	if p.Radius > 0 {
+		set("radius", formatFloat(p.Radius))
+		set("radius_units", string(p.RadiusUnits))
+	}
+	set("license", strings.Join(p.Licenses, ","))
+	set("media", string(p.Media))
+	if p.ContentType != NoContentTypeSpecified {
+		set("content_type", strconv.Itoa(int(p.ContentType)))
+	}
+	if p.SafeSearch != NoSafetySpecified {
+		set("safe_search", strconv.Itoa(int(p.SafeSearch)))
+	}
+	set("sort", string(p.Sort))
+	set("extras", p.Extras.String())
+	if p.PerPage > 0 {
+		set("per_page", strconv.Itoa(p.PerPage))
+	}
+	if p.Page > 0 {
+		set("page", strconv.Itoa(p.Page))
+	}
+	return args
+}
+
+// Search returns a page of photos matching params, which are validated before
+// performing the request.
+// This method does not require authentication, but only public photos are
+// returned to unauthenticated calls.
+func Search(client *flickr.FlickrClient, params *SearchParams) (*SearchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return &SearchResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args = params.args()
+	client.Args.Set("method", "flickr.photos.search")
+	client.OAuthSign()
+
+	response := &SearchResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SearchIterator walks through all the pages of search results, fetching them
+// as needed. Use it like a bufio.Scanner:
+//
+//	it := photos.NewSearchIterator(client, params)
+//	for it.Next() {
+//		fmt.Println(it.Photo().Title)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SearchIterator struct {
+	client  *flickr.FlickrClient
+	params  SearchParams
+	photos  []Photo
+	current Photo
+	pages   int
+	total   int
+	err     error
+	done    bool
+}
+
+// NewSearchIterator returns an iterator over all the photos matching params,
+// starting from params.Page
+func NewSearchIterator(client *flickr.FlickrClient, params *SearchParams) *SearchIterator {
+	it := &SearchIterator{client: client, params: *params}
+	if it.params.Page < 1 {
+		it.params.Page = 1
+	}
+	return it
+}
+
+// Next advances to the next photo, fetching a new page if needed. It returns
+// false when there are no more photos or an error occurred.
+func (it *SearchIterator) Next() bool {
+	for len(it.photos) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		response, err := Search(it.client, &it.params)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.photos = response.Photos.Photos
+		it.pages = response.Photos.Pages
+		it.total = response.Photos.Total
+		// an empty page means we went past the last one
+		it.done = it.params.Page >= it.pages || len(it.photos) == 0
+		it.params.Page++
+	}
+
+	it.current = it.photos[0]
+	it.photos = it.photos[1:]
+	return true
+}
+
+// Photo returns the current photo
+func (it *SearchIterator) Photo() Photo {
+	return it.current
+}
+
+// Total returns the number of photos matching the search, available after the
+// first call to Next
+func (it *SearchIterator) Total() int {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SearchIterator) Err() error {
+	return it.err
+}
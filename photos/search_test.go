@@ -0,0 +1,159 @@
+package photos
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func TestSearchParamsValidate(t *testing.T) {
+	params := &SearchParams{}
+	err := params.Validate()
+	flickr.ExpectValidationError(t, err)
+
+	params = &SearchParams{Text: "duomo", Point: &GeoPoint{45.46, 9.19}, Radius: 5}
+	flickr.Expect(t, params.Validate(), nil)
+
+	bad := []*SearchParams{
+		{Text: "duomo", TagMode: TagModeAll},
+		{Tags: []string{"milano"}, TagMode: "some"},
+		{MachineTagMode: TagModeAny, Text: "duomo"},
+		{MinUploadDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), MaxUploadDate: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{MinTakenDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), MaxTakenDate: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{BBox: &BoundingBox{9, 45, 10, 46}, Point: &GeoPoint{45.46, 9.19}},
+		{BBox: &BoundingBox{10, 45, 9, 46}},
+		{BBox: &BoundingBox{-190, 45, 9, 46}},
+		{Point: &GeoPoint{95, 9}},
+		{Text: "duomo", Radius: 5},
+		{Point: &GeoPoint{45.46, 9.19}, Radius: 40},
+		{Point: &GeoPoint{45.46, 9.19}, Radius: 25, RadiusUnits: Miles},
+		{Point: &GeoPoint{45.46, 9.19}, Radius: 5, RadiusUnits: "ft"},
+		{Text: "duomo", Media: "gifs"},
+		{Text: "duomo", ContentType: 8},
+		{Text: "duomo", SafeSearch: 4},
+		{Text: "duomo", Sort: "random"},
+		{Text: "duomo", PerPage: 501},
+		{Text: "duomo", Page: -1},
+	}
+	for i, p := range bad {
+		if p.Validate() == nil {
+			t.Errorf("params %d should not be valid", i)
+		}
+	}
+
+	// every problem is reported
+	err = (&SearchParams{Text: "duomo", Sort: "random", PerPage: 501}).Validate()
+	flickr.Expect(t, strings.Count(err.Error(), ";"), 1)
+}
+
+func TestSearch(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="2" pages="3" perpage="2" total="5">
+    <photo id="111" owner="12345@N00" secret="abc" server="65535" farm="66" title="Duomo" ispublic="1" isfriend="0" isfamily="0" url_m="https://live.staticflickr.com/65535/111_abc.jpg" />
+    <photo id="222" owner="12345@N00" secret="def" server="65535" farm="66" title="Navigli" ispublic="1" isfriend="0" isfamily="0" />
+  </photos>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := &SearchParams{
+		Text:          "milano",
+		Tags:          []string{"duomo", "navigli"},
+		TagMode:       TagModeAll,
+		MinUploadDate: time.Unix(1500000000, 0),
+		MaxTakenDate:  time.Date(2020, 5, 4, 10, 30, 0, 0, time.UTC),
+		BBox:          &BoundingBox{9.1, 45.4, 9.25, 45.5},
+		Licenses:      []string{"4", "5"},
+		ContentType:   PhotosOnly,
+		SafeSearch:    Safe,
+		Sort:          InterestingnessDesc,
+		Extras:        flickr.Extras{flickr.ExtraURLMedium500, flickr.ExtraDateTaken},
+		PerPage:       2,
+		Page:          2,
+	}
+	resp, err := Search(fclient, params)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Photos.Page, 2)
+	flickr.Expect(t, resp.Photos.Total, 5)
+	flickr.Expect(t, len(resp.Photos.Photos), 2)
+	flickr.Expect(t, resp.Photos.Photos[0].Title, "Duomo")
+	flickr.Expect(t, resp.Photos.Photos[0].IsPublic, true)
+	flickr.Expect(t, resp.Photos.Photos[0].URLs[flickr.SizeMedium500].URL, "https://live.staticflickr.com/65535/111_abc.jpg")
+
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.search")
+	flickr.Expect(t, fclient.Args.Get("tags"), "duomo,navigli")
+	flickr.Expect(t, fclient.Args.Get("tag_mode"), "all")
+	flickr.Expect(t, fclient.Args.Get("min_upload_date"), "1500000000")
+	flickr.Expect(t, fclient.Args.Get("max_taken_date"), "2020-05-04 10:30:00")
+	flickr.Expect(t, fclient.Args.Get("bbox"), "9.1,45.4,9.25,45.5")
+	flickr.Expect(t, fclient.Args.Get("license"), "4,5")
+	flickr.Expect(t, fclient.Args.Get("content_type"), "1")
+	flickr.Expect(t, fclient.Args.Get("safe_search"), "1")
+	flickr.Expect(t, fclient.Args.Get("sort"), "interestingness-desc")
+	flickr.Expect(t, fclient.Args.Get("extras"), "url_m,date_taken")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "2")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	// unset parameters are not sent
+	_, ok := fclient.Args["media"]
+	flickr.Expect(t, ok, false)
+	_, ok = fclient.Args["lat"]
+	flickr.Expect(t, ok, false)
+
+	// invalid params don't hit the network
+	fclient = flickr.GetTestClient()
+	resp, err = Search(fclient, &SearchParams{})
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, resp != nil, true)
+	flickr.Expect(t, fclient.Args.Get("method"), "")
+}
+
+func TestSearchIterator(t *testing.T) {
+	pages := []string{
+		`<photo id="1" /><photo id="2" />`,
+		`<photo id="3" /><photo id="4" />`,
+		`<photo id="5" />`,
+	}
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseMultipartForm(1 << 20)
+		page := r.FormValue("page")
+		requested = append(requested, page)
+		var n int
+		fmt.Sscan(page, &n)
+		fmt.Fprintf(w, `<rsp stat="ok"><photos page="%d" pages="3" perpage="2" total="5">%s</photos></rsp>`, n, pages[n-1])
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	fclient := flickr.GetTestClient()
+	fclient.HTTPClient = &http.Client{Transport: flickr.RewriteTransport{URL: u}}
+
+	params := &SearchParams{UserId: "me", PerPage: 2}
+	it := NewSearchIterator(fclient, params)
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Photo().Id)
+	}
+	flickr.Expect(t, it.Err(), nil)
+	flickr.Expect(t, it.Total(), 5)
+	flickr.Expect(t, strings.Join(ids, ","), "1,2,3,4,5")
+	flickr.Expect(t, strings.Join(requested, ","), "1,2,3")
+	flickr.Expect(t, it.Next(), false)
+	// caller params untouched
+	flickr.Expect(t, params.Page, 0)
+
+	// errors stop the iteration
+	server2, client := flickr.FlickrMock(200, `<rsp stat="fail"><err code="1" msg="Too many tags in ALL query" /></rsp>`, "")
+	defer server2.Close()
+	fclient.HTTPClient = client
+	it = NewSearchIterator(fclient, params)
+	flickr.Expect(t, it.Next(), false)
+	flickr.Expect(t, it.Err() != nil, true)
+}
@@ -0,0 +1,245 @@
+package photos
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// PermLevel is who can comment on or add metadata to a photo
+type PermLevel int
+
+const (
+	NoPermSpecified PermLevel = iota
+	PermOwnerOnly
+	PermFriendsAndFamily
+	PermContacts
+	PermEverybody
+)
+
+// Perms are the full set of permissions of a photo, PermComment and
+// PermAddMeta are optional
+type Perms struct {
+	IsPublic    bool
+	IsFriend    bool
+	IsFamily    bool
+	PermComment PermLevel
+	PermAddMeta PermLevel
+}
+
+// HiddenState tells whether a photo is hidden from public searches
+type HiddenState int
+
+const (
+	NoHiddenSpecified HiddenState = iota
+	VisibleInSearches
+	HiddenFromSearches
+)
+
+// LicenseId identifies the licenses listed by flickr.photos.licenses.getInfo
+type LicenseId int
+
+const (
+	AllRightsReserved LicenseId = iota
+	CCByNcSa2
+	CCByNc2
+	CCByNcNd2
+	CCBy2
+	CCBySa2
+	CCByNd2
+	NoKnownCopyrightRestrictions
+	USGovernmentWork
+	PublicDomainDedication
+	PublicDomainMark
+	CCBy4
+	CCBySa4
+	CCByNd4
+	CCByNc4
+	CCByNcSa4
+	CCByNcNd4
+)
+
+func (l PermLevel) validate() error {
+	if l < NoPermSpecified || l > PermEverybody {
+		return flickErr.NewError(flickErr.ValidationError, fmt.Sprintf("unknown permission level %d", l))
+	}
+	return nil
+}
+
+func boolArg(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// SetMeta sets title and description of a photo.
+// This method requires authentication with 'write' permission.
+func SetMeta(client *flickr.FlickrClient, id string, title string, description string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setMeta")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("title", title)
+	client.Args.Set("description", description)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SetTags replaces all the tags of a photo, tags containing spaces are quoted
+// as Flickr expects. Pass an empty list to remove all the tags.
+// This method requires authentication with 'write' permission.
+func SetTags(client *flickr.FlickrClient, id string, tags []string) (*flickr.BasicResponse, error) {
+	quoted := make([]string, len(tags))
+	for i, tag := range tags {
+		if strings.Contains(tag, " ") && !strings.HasPrefix(tag, `"`) {
+			tag = `"` + tag + `"`
+		}
+		quoted[i] = tag
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setTags")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("tags", strings.Join(quoted, " "))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// RemoveTag removes a tag from a photo, tagId is the ID field of Tag as
+// returned by GetInfo.
+// This method requires authentication with 'write' permission.
+func RemoveTag(client *flickr.FlickrClient, tagId string) (*flickr.BasicResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.removeTag")
+	client.Args.Set("tag_id", tagId)
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SetContentType sets the content type of a photo, only PhotosOnly,
+// ScreenShotsOnly and OtherOnly are allowed.
+// This method requires authentication with 'write' permission.
+func SetContentType(client *flickr.FlickrClient, id string, contentType ContentType) (*flickr.BasicResponse, error) {
+	if contentType < PhotosOnly || contentType > OtherOnly {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("content type %d can't be set on a photo", contentType))
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setContentType")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("content_type", strconv.Itoa(int(contentType)))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SetSafetyLevel sets the safety level of a photo and whether it's hidden
+// from public searches, at least one of them must be specified.
+// This method requires authentication with 'write' permission.
+func SetSafetyLevel(client *flickr.FlickrClient, id string, level SafetyLevel, hidden HiddenState) (*flickr.BasicResponse, error) {
+	if level < NoSafetySpecified || level > Restricted {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("unknown safety level %d", level))
+	}
+	if hidden < NoHiddenSpecified || hidden > HiddenFromSearches {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("unknown hidden state %d", hidden))
+	}
+	if level == NoSafetySpecified && hidden == NoHiddenSpecified {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError, "either safety level or hidden state is required")
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setSafetyLevel")
+	client.Args.Set("photo_id", id)
+	if level != NoSafetySpecified {
+		client.Args.Set("safety_level", strconv.Itoa(int(level)))
+	}
+	if hidden != NoHiddenSpecified {
+		client.Args.Set("hidden", boolArg(hidden == HiddenFromSearches))
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SetPermissions sets visibility, comment and metadata permissions of a photo,
+// unlike SetPerms it also handles who can comment and add metadata.
+// This method requires authentication with 'write' permission.
+func SetPermissions(client *flickr.FlickrClient, id string, perms Perms) (*flickr.BasicResponse, error) {
+	if err := perms.PermComment.validate(); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+	if err := perms.PermAddMeta.validate(); err != nil {
+		return &flickr.BasicResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.setPerms")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("is_public", boolArg(perms.IsPublic))
+	client.Args.Set("is_friend", boolArg(perms.IsFriend))
+	client.Args.Set("is_family", boolArg(perms.IsFamily))
+	// Flickr levels start from 0, which we use as not specified
+	if perms.PermComment != NoPermSpecified {
+		client.Args.Set("perm_comment", strconv.Itoa(int(perms.PermComment)-1))
+	}
+	if perms.PermAddMeta != NoPermSpecified {
+		client.Args.Set("perm_addmeta", strconv.Itoa(int(perms.PermAddMeta)-1))
+	}
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// SetLicense sets the license of a photo.
+// This method requires authentication with 'write' permission.
+func SetLicense(client *flickr.FlickrClient, id string, license LicenseId) (*flickr.BasicResponse, error) {
+	if license < AllRightsReserved || license > CCByNcNd4 {
+		return &flickr.BasicResponse{}, flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("unknown license %d", license))
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.licenses.setLicense")
+	client.Args.Set("photo_id", id)
+	client.Args.Set("license_id", strconv.Itoa(int(license)))
+	client.OAuthSign()
+
+	response := &flickr.BasicResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -0,0 +1,117 @@
+package photos
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func TestSetMeta(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err := SetMeta(fclient, "123", "Duomo", "")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setMeta")
+	flickr.Expect(t, fclient.Args.Get("title"), "Duomo")
+	// an empty description clears it
+	_, ok := fclient.Args["description"]
+	flickr.Expect(t, ok, true)
+}
+
+func TestSetTagsRemoveTag(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err := SetTags(fclient, "123", []string{"milano", "gothic architecture", `"already quoted"`})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setTags")
+	flickr.Expect(t, fclient.Args.Get("tags"), `milano "gothic architecture" "already quoted"`)
+
+	_, err = RemoveTag(fclient, "41641790-52435165562-7257133")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.removeTag")
+	flickr.Expect(t, fclient.Args.Get("tag_id"), "41641790-52435165562-7257133")
+}
+
+func TestSetContentType(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err := SetContentType(fclient, "123", ScreenShotsOnly)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("content_type"), "2")
+
+	_, err = SetContentType(fclient, "123", PhotosAndOther)
+	flickr.ExpectValidationError(t, err)
+	_, err = SetContentType(fclient, "123", NoContentTypeSpecified)
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestSetSafetyLevel(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err := SetSafetyLevel(fclient, "123", Moderate, HiddenFromSearches)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setSafetyLevel")
+	flickr.Expect(t, fclient.Args.Get("safety_level"), "2")
+	flickr.Expect(t, fclient.Args.Get("hidden"), "1")
+
+	_, err = SetSafetyLevel(fclient, "123", NoSafetySpecified, VisibleInSearches)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("hidden"), "0")
+	_, ok := fclient.Args["safety_level"]
+	flickr.Expect(t, ok, false)
+
+	_, err = SetSafetyLevel(fclient, "123", NoSafetySpecified, NoHiddenSpecified)
+	flickr.ExpectValidationError(t, err)
+	_, err = SetSafetyLevel(fclient, "123", 4, NoHiddenSpecified)
+	flickr.ExpectValidationError(t, err)
+	_, err = SetSafetyLevel(fclient, "123", Safe, 3)
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestSetPermissions(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err := SetPermissions(fclient, "123", Perms{IsFriend: true, PermComment: PermOwnerOnly, PermAddMeta: PermEverybody})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.setPerms")
+	flickr.Expect(t, fclient.Args.Get("is_public"), "0")
+	flickr.Expect(t, fclient.Args.Get("is_friend"), "1")
+	flickr.Expect(t, fclient.Args.Get("perm_comment"), "0")
+	flickr.Expect(t, fclient.Args.Get("perm_addmeta"), "3")
+
+	_, err = SetPermissions(fclient, "123", Perms{IsPublic: true})
+	flickr.Expect(t, err, nil)
+	_, ok := fclient.Args["perm_comment"]
+	flickr.Expect(t, ok, false)
+
+	_, err = SetPermissions(fclient, "123", Perms{PermAddMeta: 5})
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestSetLicense(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?><rsp stat="ok"></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+	_, err := SetLicense(fclient, "123", CCBySa4)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.licenses.setLicense")
+	flickr.Expect(t, fclient.Args.Get("license_id"), "12")
+
+	_, err = SetLicense(fclient, "123", AllRightsReserved)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("license_id"), "0")
+
+	_, err = SetLicense(fclient, "123", 17)
+	flickr.ExpectValidationError(t, err)
+}
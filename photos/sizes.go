@@ -0,0 +1,43 @@
+package photos
+
+import (
+	"strconv"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+// URL builds the static URL of the photo in the given size, see flickr.PhotoURL
+func (p *PhotoInfo) URL(size flickr.PhotoSize) (string, error) {
+	return flickr.PhotoURL(p.Server, p.Id, p.Secret, p.OriginalSecret, p.OriginalFormat, size)
+}
+
+// BestSize returns the smallest image at least width x height pixels big,
+// or the largest one available if none is big enough. Zero width or height
+// means any. Video sizes are ignored, ok is false if there are no image sizes.
+func (info *PhotoAccessInfo) BestSize(width, height int) (size PhotoDownloadInfo, ok bool) {
+	var best, largest PhotoDownloadInfo
+	var bestArea, largestArea int
+	for _, s := range info.Sizes {
+		if s.Media != "" && s.Media != "photo" {
+			continue
+		}
+		w, errW := strconv.Atoi(s.Width)
+		h, errH := strconv.Atoi(s.Height)
+		if errW != nil || errH != nil {
+			continue
+		}
+
+		area := w * h
+		if area > largestArea {
+			largest, largestArea = s, area
+		}
+		if w >= width && h >= height && (bestArea == 0 || area < bestArea) {
+			best, bestArea = s, area
+		}
+	}
+
+	if bestArea > 0 {
+		return best, true
+	}
+	return largest, largestArea > 0
+}
@@ -0,0 +1,50 @@
+package photos
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+)
+
+func TestURL(t *testing.T) {
+	info := &PhotoInfo{Id: "52435165562", Secret: "abc", Server: "65535", OriginalSecret: "9", OriginalFormat: "jpg"}
+	url, err := info.URL(flickr.SizeMedium800)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, url, "https://live.staticflickr.com/65535/52435165562_abc_c.jpg")
+	url, err = info.URL(flickr.SizeOriginal)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, url, "https://live.staticflickr.com/65535/52435165562_9_o.jpg")
+
+	photo := &Photo{Id: "111", Secret: "abc", Server: "65535"}
+	url, err = photo.URL(flickr.SizeThumbnail100)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, url, "https://live.staticflickr.com/65535/111_abc_t.jpg")
+	_, err = photo.URL(flickr.SizeOriginal)
+	flickr.Expect(t, err != nil, true)
+}
+
+func TestBestSize(t *testing.T) {
+	info := &PhotoAccessInfo{Sizes: append([]PhotoDownloadInfo{
+		{Label: "Site MP4", Width: "5000", Height: "4000", Media: "video"},
+	}, sampleSizes.Sizes...)}
+
+	size, ok := info.BestSize(400, 0)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, size.Label, "Medium")
+
+	size, ok = info.BestSize(600, 600)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, size.Label, "Large")
+
+	size, ok = info.BestSize(0, 0)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, size.Label, "Square")
+
+	// nothing big enough, videos are ignored
+	size, ok = info.BestSize(8000, 0)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, size.Label, "Original")
+
+	_, ok = (&PhotoAccessInfo{}).BestSize(100, 100)
+	flickr.Expect(t, ok, false)
+}
@@ -0,0 +1,61 @@
+package photos
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// Rotation is how many degrees a photo is rotated clockwise
+type Rotation int
+
+const (
+	Rotate90  Rotation = 90
+	Rotate180 Rotation = 180
+	Rotate270 Rotation = 270
+)
+
+// RotateResponse contains the secrets of the rotated photo, they change
+// because the static URLs of the previous images are invalidated
+type RotateResponse struct {
+	flickr.BasicResponse
+	Photo struct {
+		Id             string `xml:",chardata"`
+		Secret         string `xml:"secret,attr"`
+		OriginalSecret string `xml:"originalsecret,attr"`
+	} `xml:"photoid"`
+}
+
+// Rotate rotates a photo clockwise by the given degrees.
+// This method requires authentication with 'write' permission.
+func Rotate(client *flickr.FlickrClient, photoId string, degrees Rotation) (*RotateResponse, error) {
+	if degrees != Rotate90 && degrees != Rotate180 && degrees != Rotate270 {
+		return &RotateResponse{}, flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("rotation must be 90, 180 or 270 degrees, got %d", degrees))
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photos.transform.rotate")
+	client.Args.Set("photo_id", photoId)
+	client.Args.Set("degrees", strconv.Itoa(int(degrees)))
+	client.OAuthSign()
+
+	response := &RotateResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// RotateAndGetInfo rotates a photo and returns its info once rotated, with the
+// new secrets and the total rotation applied so far.
+// This method requires authentication with 'write' permission.
+func RotateAndGetInfo(client *flickr.FlickrClient, photoId string, degrees Rotation) (*PhotoInfoResponse, error) {
+	rotated, err := Rotate(client, photoId, degrees)
+	if err != nil {
+		return &PhotoInfoResponse{}, err
+	}
+	return GetInfo(client, photoId, rotated.Photo.Secret)
+}
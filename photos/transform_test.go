@@ -0,0 +1,64 @@
+package photos
+
+import (
+	"testing"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+func TestRotate(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok"><photoid secret="f00" originalsecret="ba7">2733</photoid></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := Rotate(fclient, "2733", Rotate270)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.photos.transform.rotate")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2733")
+	flickr.Expect(t, fclient.Args.Get("degrees"), "270")
+	flickr.Expect(t, resp.Photo.Id, "2733")
+	flickr.Expect(t, resp.Photo.Secret, "f00")
+	flickr.Expect(t, resp.Photo.OriginalSecret, "ba7")
+
+	for _, degrees := range []Rotation{0, 45, 360, -90} {
+		resp, err = Rotate(fclient, "2733", degrees)
+		flickr.ExpectValidationError(t, err)
+		flickr.Expect(t, resp != nil, true)
+	}
+}
+
+func TestRotateAndGetInfo(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client, called := flickr.FlickrMethodsMock(map[string]string{
+		"flickr.photos.transform.rotate": `<rsp stat="ok"><photoid secret="f00" originalsecret="ba7">2733</photoid></rsp>`,
+		"flickr.photos.getInfo":          `<rsp stat="ok"><photo id="2733" secret="f00" originalsecret="ba7" rotation="180" /></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := RotateAndGetInfo(fclient, "2733", Rotate90)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(*called), 2)
+	flickr.Expect(t, (*called)[1], "flickr.photos.getInfo")
+	flickr.Expect(t, fclient.Args.Get("secret"), "f00")
+	flickr.Expect(t, resp.Photo.Secret, "f00")
+	flickr.Expect(t, resp.Photo.OriginalSecret, "ba7")
+	flickr.Expect(t, resp.Photo.Rotation, 180)
+
+	// no info is requested when the rotation fails
+	server, client, called = flickr.FlickrMethodsMock(map[string]string{
+		"flickr.photos.transform.rotate": `<rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err = RotateAndGetInfo(fclient, "2733", Rotate90)
+	flickr.Expect(t, resp != nil, true)
+	ee, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.ApiError)
+	flickr.Expect(t, len(*called), 1)
+}
@@ -2,69 +2,66 @@
 package photosets
 
 import (
+	"encoding/xml"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/masci/flickr.v3"
+	"gopkg.in/masci/flickr.v3/photos"
 )
 
 type Photoset struct {
-	Id                string `xml:"id,attr"`
-	Primary           string `xml:"primary,attr"`
-	Secret            string `xml:"secret,attr"`
-	Server            string `xml:"server,attr"`
-	Farm              string `xml:"farm,attr"`
-	Photos            int    `xml:"photos,attr"`
-	Videos            int    `xml:"videos,attr"`
-	NeedsInterstitial bool   `xml:"needs_interstitial,attr"`
-	VisCanSeeSet      bool   `xml:"visibility_can_see_set,attr"`
-	CountViews        int    `xml:"count_views,attr"`
-	CountComments     int    `xml:"count_comments,attr"`
-	CanComment        bool   `xml:"can_comment,attr"`
-	DateCreate        int    `xml:"date_create,attr"`
-	DateUpdate        int    `xml:"date_update,attr"`
-	Title             string `xml:"title"`
-	Description       string `xml:"description"`
-	Url               string `xml:"url,attr"`
-	Owner             string `xml:"owner,attr"`
+	Id                string    `xml:"id,attr"`
+	Primary           string    `xml:"primary,attr"`
+	Secret            string    `xml:"secret,attr"`
+	Server            string    `xml:"server,attr"`
+	Farm              string    `xml:"farm,attr"`
+	Photos            int       `xml:"photos,attr"`
+	Videos            int       `xml:"videos,attr"`
+	NeedsInterstitial bool      `xml:"needs_interstitial,attr"`
+	VisCanSeeSet      bool      `xml:"visibility_can_see_set,attr"`
+	CountViews        int       `xml:"count_views,attr"`
+	CountComments     int       `xml:"count_comments,attr"`
+	CanComment        bool      `xml:"can_comment,attr"`
+	DateCreate        time.Time `xml:"-"`
+	DateUpdate        time.Time `xml:"-"`
+	Title             string    `xml:"title"`
+	Description       string    `xml:"description"`
+	Url               string    `xml:"url,attr"`
+	Owner             string    `xml:"owner,attr"`
 }
 
-type Photo struct {
-	Id             string `xml:"id,attr"`
-	Title          string `xml:"title,attr"`
-	Secret         string `xml:"secret,attr"`
-	OriginalSecret string `xml:"originalsecret,attr"`
-	OriginalFormat string `xml:"originalformat,attr"`
-	Server         int    `xml:"server,attr"`
-	Farm           int    `xml:"farm,attr"`
-	Isprimary      string `xml:"isprimary,attr"`
-	IsPublic       string `xml:"ispublic,attr"`
-	IsFriend       string `xml:"isfriend,attr"`
-	IsFamily       string `xml:"isfamily,attr"`
-	URLC           string `xml:"url_c,attr"` // URL of medium 800, 800 on longest size image
-	HeightC        string `xml:"height_c,attr"`
-	WidthC         string `xml:"width_c,attr"`
-	URLM           string `xml:"url_m,attr"` // URL of small, medium size image
-	HeightM        string `xml:"height_m,attr"`
-	WidthM         string `xml:"width_m,attr"`
-	URLN           string `xml:"url_n,attr"` // URL of small, 320 on longest side size image
-	HeightN        string `xml:"height_n,attr"`
-	WidthN         string `xml:"width_n,attr"`
-	URLO           string `xml:"url_o,attr"` // URL of original size image
-	HeightO        string `xml:"height_o,attr"`
-	WidthO         string `xml:"width_o,attr"`
-	URLQ           string `xml:"url_q,attr"` // URL of large square 150x150 size image
-	HeightQ        string `xml:"height_q,attr"`
-	WidthQ         string `xml:"width_q,attr"`
-	URLS           string `xml:"url_s,attr"` // URL of small square 75x75 size image
-	HeightS        string `xml:"height_s,attr"`
-	WidthS         string `xml:"width_s,attr"`
-	URLSQ          string `xml:"url_sq,attr"` // URL of small square 75x75 size image
-	HeightSQ       string `xml:"height_sq,attr"`
-	WidthSQ        string `xml:"width_sq,attr"`
-	URLT           string `xml:"url_t,attr"` // URL of thumbnail, 100 on longest side size image
-	HeightT        string `xml:"height_t,attr"`
-	WidthT         string `xml:"width_t,attr"`
+// UnmarshalXML decodes the unix timestamps in date_create and date_update
+func (s *Photoset) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type photoset Photoset
+	raw := struct {
+		*photoset
+		DateCreate string `xml:"date_create,attr"`
+		DateUpdate string `xml:"date_update,attr"`
+	}{photoset: (*photoset)(s)}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	s.DateCreate = flickr.ParseUnixTime(raw.DateCreate)
+	s.DateUpdate = flickr.ParseUnixTime(raw.DateUpdate)
+	return nil
+}
+
+// Photo is an item of a photoset, see flickr.Photo
+type Photo = flickr.Photo
+
+// DefaultExtras are the extras requested by GetPhotos
+var DefaultExtras = flickr.Extras{
+	flickr.ExtraOriginalFormat,
+	flickr.ExtraURLMedium800,
+	flickr.ExtraURLMedium500,
+	flickr.ExtraURLSmall320,
+	flickr.ExtraURLOriginal,
+	flickr.ExtraURLSquare150,
+	flickr.ExtraURLSmall240,
+	flickr.ExtraURLSquare75,
+	flickr.ExtraURLThumbnail100,
 }
 
 type PhotosetsListResponse struct {
@@ -184,12 +181,21 @@ func RemovePhoto(client *flickr.FlickrClient, photosetId, photoId string) (*flic
 	return response, err
 }
 
-// Get the photos in a set
+// Get the photos in a set along with DefaultExtras
 // This method requires authentication to retrieve photos from private sets
 func GetPhotos(client *flickr.FlickrClient, authenticate bool, photosetId, ownerID string, page int) (*PhotosListResponse, error) {
+	return GetPhotosWithExtras(client, authenticate, photosetId, ownerID, page, DefaultExtras)
+}
+
+// Same as GetPhotos, requesting the given extras, nil extras requests DefaultExtras
+func GetPhotosWithExtras(client *flickr.FlickrClient, authenticate bool, photosetId, ownerID string, page int, extras flickr.Extras) (*PhotosListResponse, error) {
+	if extras == nil {
+		extras = DefaultExtras
+	}
+
 	client.Init()
 	client.Args.Set("method", "flickr.photosets.getPhotos")
-	client.Args.Set("extras", "original_format,url_c,url_m,url_n,url_o,url_q,url_s,url_sq,url_t")
+	client.Args.Set("extras", extras.String())
 	client.Args.Set("photoset_id", photosetId)
 	// this argument is optional but increases query performances
 	if ownerID != "" {
@@ -325,3 +331,18 @@ func SetPrimaryPhoto(client *flickr.FlickrClient, photosetId, primaryId string)
 	err := flickr.DoPost(client, response)
 	return response, err
 }
+
+// Get the photos before and after the given one in a photoset
+func GetContext(client *flickr.FlickrClient, photosetId, photoId string) (*photos.ContextResponse, error) {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.photosets.getContext")
+	client.Args.Set("photoset_id", photosetId)
+	client.Args.Set("photo_id", photoId)
+
+	client.OAuthSign()
+
+	response := &photos.ContextResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
@@ -2,6 +2,7 @@ package photosets
 
 import (
 	"testing"
+	"time"
 
 	"gopkg.in/masci/flickr.v3"
 	flickErr "gopkg.in/masci/flickr.v3/error"
@@ -55,8 +56,8 @@ func TestGetList(t *testing.T) {
 	flickr.Expect(t, set1.CountViews, 999)
 	flickr.Expect(t, set1.CountComments, 777)
 	flickr.Expect(t, set1.CanComment, false)
-	flickr.Expect(t, set1.DateCreate, 1361132046)
-	flickr.Expect(t, set1.DateUpdate, 1376079704)
+	flickr.Expect(t, set1.DateCreate.Equal(time.Unix(1361132046, 0)), true)
+	flickr.Expect(t, set1.DateUpdate.Equal(time.Unix(1376079704, 0)), true)
 	flickr.Expect(t, set1.Title, "A photoset")
 	flickr.Expect(t, set1.Description, "")
 
@@ -204,6 +205,13 @@ func TestGetPhotos(t *testing.T) {
 	resp, err := GetPhotos(fclient, false, "72157654991267328", "126545133@N08", 1)
 	flickr.Expect(t, err, nil)
 	flickr.Expect(t, len(resp.Photoset.Photos), 3)
+	flickr.Expect(t, resp.Photoset.Photos[0].IsPrimary, true)
+	flickr.Expect(t, resp.Photoset.Photos[1].Farm, 9)
+	flickr.Expect(t, fclient.Args.Get("extras"), "original_format,url_c,url_m,url_n,url_o,url_q,url_s,url_sq,url_t")
+
+	_, err = GetPhotosWithExtras(fclient, false, "72157654991267328", "", 1, flickr.Extras{flickr.ExtraViews, flickr.ExtraViews, flickr.ExtraTags})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("extras"), "views,tags")
 
 	server, client = flickr.FlickrMock(200, `<rsp stat="fail"><err code="1" msg="Photoset not found" /></rsp>`, "text/xml")
 	defer server.Close()
@@ -383,3 +391,31 @@ func TestOrderSet(t *testing.T) {
 	flickr.AssertParamsInBody(t, fclient, params)
 
 }
+
+func TestGetContext(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok">
+		<count>3</count>
+		<prevphoto id="0" />
+		<nextphoto id="2985" secret="059b664012" server="2" farm="1" title="Amsterdam Amstel" url="/photos/bees/2985/in/set-72157654991267328/" />
+	</rsp>`, "text/xml")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetContext(fclient, "72157654991267328", "2983")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, resp.Count, 3)
+	flickr.Expect(t, len(resp.PrevPhotos()), 0)
+	flickr.Expect(t, resp.NextPhotos()[0].Id, "2985")
+
+	fclient = flickr.GetTestClient()
+	GetContext(fclient, "72157654991267328", "2983")
+	flickr.AssertParamsInBody(t, fclient, []string{"photoset_id", "photo_id"})
+}
+
+func TestPhotoURL(t *testing.T) {
+	photo := Photo{Id: "2636", Secret: "a123456", Server: "2"}
+	url, err := photo.URL(flickr.SizeMedium500)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, url, "https://live.staticflickr.com/2/2636_a123456.jpg")
+}
@@ -0,0 +1,220 @@
+package flickr
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// PostUploadStep is the outcome of a single action performed on a photo
+// right after it was uploaded
+type PostUploadStep struct {
+	// Flickr API method called, ex. flickr.photosets.addPhoto
+	Method string
+	// ID or title of the photoset, ID of the group or ID of the photo itself
+	Target string
+	// ID of the photoset created for a title that didn't exist yet
+	CreatedID string
+	Err       error
+}
+
+// PostUploadResponse wraps the upload response with the results of
+// the steps performed afterwards
+type PostUploadResponse struct {
+	*UploadResponse
+	Steps []PostUploadStep
+}
+
+// Return the steps that didn't succeed, if any
+func (r *PostUploadResponse) FailedSteps() []PostUploadStep {
+	return failedSteps(r.Steps)
+}
+
+func failedSteps(steps []PostUploadStep) []PostUploadStep {
+	var ret []PostUploadStep
+	for _, s := range steps {
+		if s.Err != nil {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// APICall is a Flickr API method along with its arguments
+type APICall struct {
+	Method string
+	Args   url.Values
+}
+
+// Do performs the call as a signed POST request, unmarshalling the results in r
+func (c APICall) Do(client *FlickrClient, r FlickrResponse) error {
+	return postMethod(client, c.Method, c.Args, r)
+}
+
+// FollowUpCalls returns the API calls needed to set on an uploaded photo the
+// parameters the upload endpoint doesn't accept: license, location and date taken.
+// Photosets and groups are not included, see OrganizePhoto.
+func (params *UploadParams) FollowUpCalls(photoId string) []APICall {
+	var calls []APICall
+	if params.LicenseId != "" {
+		calls = append(calls, APICall{
+			Method: "flickr.photos.licenses.setLicense",
+			Args:   url.Values{"photo_id": {photoId}, "license_id": {params.LicenseId}},
+		})
+	}
+	if params.Geo != nil {
+		args := url.Values{
+			"photo_id": {photoId},
+			"lat":      {strconv.FormatFloat(params.Geo.Latitude, 'f', 6, 64)},
+			"lon":      {strconv.FormatFloat(params.Geo.Longitude, 'f', 6, 64)},
+		}
+		if params.Geo.Accuracy != NoAccuracySpecified {
+			args.Set("accuracy", strconv.Itoa(int(params.Geo.Accuracy)))
+		}
+		calls = append(calls, APICall{Method: "flickr.photos.geo.setLocation", Args: args})
+	}
+	if !params.DateTaken.IsZero() {
+		calls = append(calls, APICall{
+			Method: "flickr.photos.setDates",
+			Args:   url.Values{"photo_id": {photoId}, "date_taken": {FormatMySQLDatetime(params.DateTaken)}},
+		})
+	}
+	return calls
+}
+
+// Minimal flickr.photosets.getList response, only what's needed to lookup sets by title
+type photosetTitlesResponse struct {
+	BasicResponse
+	Photosets struct {
+		Pages int `xml:"pages,attr"`
+		Items []struct {
+			Id    string `xml:"id,attr"`
+			Title string `xml:"title"`
+		} `xml:"photoset"`
+	} `xml:"photosets"`
+}
+
+// Minimal flickr.photosets.create response
+type photosetCreateResponse struct {
+	BasicResponse
+	Set struct {
+		Id string `xml:"id,attr"`
+	} `xml:"photoset"`
+}
+
+// Perform a signed POST request for an API method implemented in a subpackage,
+// which can't be imported from here
+func postMethod(client *FlickrClient, method string, args url.Values, r FlickrResponse) error {
+	client.Init()
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", method)
+	for k, v := range args {
+		client.Args[k] = v
+	}
+	client.OAuthSign()
+
+	return DoPost(client, r)
+}
+
+// Map the titles of the calling user's photosets to their IDs
+func photosetIdsByTitle(client *FlickrClient) (map[string]string, error) {
+	ret := map[string]string{}
+	for page, pages := 1, 1; page <= pages; page++ {
+		response := &photosetTitlesResponse{}
+		err := postMethod(client, "flickr.photosets.getList", url.Values{"page": {strconv.Itoa(page)}}, response)
+		if err != nil {
+			return nil, err
+		}
+		for _, set := range response.Photosets.Items {
+			// keep the first one in case of duplicated titles
+			if _, ok := ret[set.Title]; !ok {
+				ret[set.Title] = set.Id
+			}
+		}
+		pages = response.Photosets.Pages
+	}
+	return ret, nil
+}
+
+// OrganizePhoto performs the FollowUpCalls of params on an already uploaded photo,
+// then adds it to the photosets and group pools listed in params. Photosets
+// referenced by title are created using the photo as primary when the calling
+// user doesn't own a set with that title yet.
+// Every step is attempted even if previous ones failed, check the Err field of
+// each returned PostUploadStep.
+// This method requires authentication with 'write' permission.
+func OrganizePhoto(client *FlickrClient, photoId string, params *UploadParams) []PostUploadStep {
+	var steps []PostUploadStep
+	if params == nil {
+		return steps
+	}
+
+	for _, call := range params.FollowUpCalls(photoId) {
+		step := PostUploadStep{Method: call.Method, Target: photoId}
+		step.Err = call.Do(client, &BasicResponse{})
+		steps = append(steps, step)
+	}
+
+	addToSet := func(setId, target string) {
+		step := PostUploadStep{Method: "flickr.photosets.addPhoto", Target: target}
+		args := url.Values{"photoset_id": {setId}, "photo_id": {photoId}}
+		step.Err = postMethod(client, step.Method, args, &BasicResponse{})
+		steps = append(steps, step)
+	}
+
+	for _, setId := range params.PhotosetIds {
+		addToSet(setId, setId)
+	}
+
+	if len(params.PhotosetTitles) > 0 {
+		sets, err := photosetIdsByTitle(client)
+		for _, title := range params.PhotosetTitles {
+			if err != nil {
+				steps = append(steps, PostUploadStep{Method: "flickr.photosets.getList", Target: title, Err: err})
+				continue
+			}
+			if setId, ok := sets[title]; ok {
+				addToSet(setId, title)
+				continue
+			}
+
+			step := PostUploadStep{Method: "flickr.photosets.create", Target: title}
+			response := &photosetCreateResponse{}
+			args := url.Values{"title": {title}, "primary_photo_id": {photoId}}
+			step.Err = postMethod(client, step.Method, args, response)
+			if step.Err == nil {
+				step.CreatedID = response.Set.Id
+				// same title listed twice, don't create another set
+				sets[title] = response.Set.Id
+			}
+			steps = append(steps, step)
+		}
+	}
+
+	for _, groupId := range params.GroupIds {
+		step := PostUploadStep{Method: "flickr.groups.pools.add", Target: groupId}
+		args := url.Values{"group_id": {groupId}, "photo_id": {photoId}}
+		step.Err = postMethod(client, step.Method, args, &BasicResponse{})
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
+// UploadFileAndOrganize performs UploadFile and then OrganizePhoto on the new photo.
+// Photos already on Flickr, skipped or replaced according to params.Duplicates,
+// are left as they are and no step is performed.
+// The returned error is only about the upload: failures occurred after the photo
+// was uploaded are reported in the Steps field of the response.
+// This call must be signed with write permissions
+func UploadFileAndOrganize(client *FlickrClient, path string, params *UploadParams) (*PostUploadResponse, error) {
+	resp, err := UploadFile(client, path, params)
+	ret := &PostUploadResponse{UploadResponse: resp}
+	if err != nil {
+		return ret, err
+	}
+
+	if !resp.Skipped && !resp.Replaced {
+		ret.Steps = OrganizePhoto(client, resp.ID, params)
+	}
+	return ret, nil
+}
@@ -0,0 +1,152 @@
+package flickr
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+const photosetTitles = `<rsp stat="ok">
+<photosets page="1" pages="1" perpage="500" total="2">
+  <photoset id="111" primary="1"><title>Holidays</title></photoset>
+  <photoset id="222" primary="2"><title>Work</title></photoset>
+</photosets>
+</rsp>`
+
+func TestOrganizePhoto(t *testing.T) {
+	fclient := GetTestClient()
+	server, client, called := FlickrMethodsMock(map[string]string{
+		"flickr.photosets.getList":  photosetTitles,
+		"flickr.photosets.addPhoto": `<rsp stat="ok"></rsp>`,
+		"flickr.photosets.create":   `<rsp stat="ok"><photoset id="333" url="http://www.flickr.com/photos/bees/sets/333/"/></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := NewUploadParams()
+	params.PhotosetIds = []string{"999"}
+	params.PhotosetTitles = []string{"Work", "Birthdays", "Birthdays"}
+	params.GroupIds = []string{"34427469792@N01"}
+
+	steps := OrganizePhoto(fclient, "1234", params)
+	Expect(t, len(steps), 5)
+
+	Expect(t, steps[0].Method, "flickr.photosets.addPhoto")
+	Expect(t, steps[0].Target, "999")
+	Expect(t, steps[0].Err, nil)
+
+	Expect(t, steps[1].Method, "flickr.photosets.addPhoto")
+	Expect(t, steps[1].Target, "Work")
+	Expect(t, steps[1].Err, nil)
+
+	Expect(t, steps[2].Method, "flickr.photosets.create")
+	Expect(t, steps[2].Target, "Birthdays")
+	Expect(t, steps[2].CreatedID, "333")
+	Expect(t, steps[2].Err, nil)
+
+	// second occurrence of the same title reuses the set just created
+	Expect(t, steps[3].Method, "flickr.photosets.addPhoto")
+	Expect(t, steps[3].Err, nil)
+
+	Expect(t, steps[4].Method, "flickr.groups.pools.add")
+	Expect(t, steps[4].Target, "34427469792@N01")
+	Expect(t, steps[4].Err != nil, true)
+
+	failed := failedSteps(steps)
+	Expect(t, len(failed), 1)
+	Expect(t, failed[0].Target, "34427469792@N01")
+
+	Expect(t, len(*called), 6)
+	Expect(t, (*called)[1], "flickr.photosets.getList")
+
+	Expect(t, len(OrganizePhoto(fclient, "1234", nil)), 0)
+}
+
+func TestOrganizePhotoGetListKo(t *testing.T) {
+	fclient := GetTestClient()
+	server, client, _ := FlickrMethodsMock(map[string]string{})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := &UploadParams{PhotosetTitles: []string{"Work", "Holidays"}}
+	steps := OrganizePhoto(fclient, "1234", params)
+	Expect(t, len(steps), 2)
+	for _, s := range steps {
+		Expect(t, s.Method, "flickr.photosets.getList")
+		Expect(t, s.Err != nil, true)
+	}
+}
+
+func TestFollowUpCalls(t *testing.T) {
+	params := NewUploadParams()
+	Expect(t, len(params.FollowUpCalls("123")), 0)
+
+	params.LicenseId = "4"
+	params.Geo = &GeoLocation{Latitude: 45.4642, Longitude: 9.19}
+	params.DateTaken = time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	calls := params.FollowUpCalls("123")
+	Expect(t, len(calls), 3)
+
+	Expect(t, calls[0].Method, "flickr.photos.licenses.setLicense")
+	Expect(t, calls[0].Args.Get("photo_id"), "123")
+	Expect(t, calls[0].Args.Get("license_id"), "4")
+
+	Expect(t, calls[1].Method, "flickr.photos.geo.setLocation")
+	Expect(t, calls[1].Args.Get("lat"), "45.464200")
+	Expect(t, calls[1].Args.Get("lon"), "9.190000")
+	_, ok := calls[1].Args["accuracy"]
+	Expect(t, ok, false)
+
+	Expect(t, calls[2].Method, "flickr.photos.setDates")
+	Expect(t, calls[2].Args.Get("date_taken"), "2001-02-03 04:05:06")
+
+	params.Geo.Accuracy = 11
+	Expect(t, params.FollowUpCalls("123")[1].Args.Get("accuracy"), "11")
+}
+
+func TestOrganizePhotoFollowUps(t *testing.T) {
+	fclient := GetTestClient()
+	server, client, called := FlickrMethodsMock(map[string]string{
+		"flickr.photos.licenses.setLicense": `<rsp stat="ok"></rsp>`,
+		"flickr.groups.pools.add":           `<rsp stat="ok"></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := &UploadParams{LicenseId: "9", Geo: &GeoLocation{}, GroupIds: []string{"1@N01"}}
+	steps := OrganizePhoto(fclient, "1234", params)
+	Expect(t, len(steps), 3)
+	Expect(t, steps[0].Err, nil)
+	Expect(t, steps[0].Target, "1234")
+	Expect(t, steps[1].Method, "flickr.photos.geo.setLocation")
+	Expect(t, steps[1].Err != nil, true)
+	Expect(t, steps[2].Err, nil)
+	Expect(t, len(*called), 3)
+}
+
+func TestUploadFileAndOrganizeSkipped(t *testing.T) {
+	f, _ := ioutil.TempFile("", "flickr.go")
+	defer os.Remove(f.Name())
+	f.Close()
+
+	fclient := GetTestClient()
+	server, client, called := FlickrMethodsMock(map[string]string{
+		"flickr.photos.search": `<rsp stat="ok"><photos><photo id="111" /></photos></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	params := NewUploadParams()
+	params.Duplicates = SkipDuplicates
+	params.LicenseId = "9"
+	params.PhotosetIds = []string{"222"}
+	resp, err := UploadFileAndOrganize(fclient, f.Name(), params)
+	Expect(t, err, nil)
+	Expect(t, resp.Skipped, true)
+	Expect(t, resp.ID, "111")
+	// the existing photo is not touched
+	Expect(t, len(resp.Steps), 0)
+	Expect(t, len(*called), 1)
+	Expect(t, (*called)[0], "flickr.photos.search")
+}
@@ -0,0 +1,96 @@
+package flickr
+
+import (
+	"fmt"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+const STATIC_ENDPOINT = "https://live.staticflickr.com/"
+
+// PhotoSize is the suffix identifying a size in static photo URLs
+type PhotoSize string
+
+const (
+	SizeSquare75     PhotoSize = "s"
+	SizeSquare150    PhotoSize = "q"
+	SizeThumbnail100 PhotoSize = "t"
+	SizeSmall240     PhotoSize = "m"
+	SizeSmall320     PhotoSize = "n"
+	SizeSmall400     PhotoSize = "w"
+	SizeMedium500    PhotoSize = ""
+	SizeMedium640    PhotoSize = "z"
+	SizeMedium800    PhotoSize = "c"
+	SizeLarge1024    PhotoSize = "b"
+	SizeLarge1600    PhotoSize = "h"
+	SizeLarge2048    PhotoSize = "k"
+	SizeXLarge3K     PhotoSize = "3k"
+	SizeXLarge4K     PhotoSize = "4k"
+	SizeXLarge4K2to1 PhotoSize = "f"
+	SizeXLarge5K     PhotoSize = "5k"
+	SizeXLarge6K     PhotoSize = "6k"
+	SizeOriginal     PhotoSize = "o"
+)
+
+// Sizes from 1600px up have their own secret, which is only returned by
+// flickr.photos.getSizes
+var ownSecretSizes = map[PhotoSize]bool{
+	SizeLarge1600:    true,
+	SizeLarge2048:    true,
+	SizeXLarge3K:     true,
+	SizeXLarge4K:     true,
+	SizeXLarge4K2to1: true,
+	SizeXLarge5K:     true,
+	SizeXLarge6K:     true,
+}
+
+// StaticURL builds the URL of a photo image in the given size without any API
+// call. Sizes with their own secret (1600px and larger) and the original can't
+// be built from the photo secret: use OriginalURL for the latter and
+// photos.GetSizes for the others.
+func StaticURL(server, id, secret string, size PhotoSize) (string, error) {
+	if size == SizeOriginal {
+		return "", flickErr.NewError(flickErr.ValidationError, "original URL requires the original secret")
+	}
+	if ownSecretSizes[size] {
+		return "", flickErr.NewError(flickErr.ValidationError,
+			fmt.Sprintf("size %q has its own secret, use photos.GetSizes", size))
+	}
+	if server == "" || id == "" || secret == "" {
+		return "", flickErr.NewError(flickErr.ValidationError, "server, id and secret are required")
+	}
+
+	if size == SizeMedium500 {
+		return fmt.Sprintf("%s%s/%s_%s.jpg", STATIC_ENDPOINT, server, id, secret), nil
+	}
+	return fmt.Sprintf("%s%s/%s_%s_%s.jpg", STATIC_ENDPOINT, server, id, secret, size), nil
+}
+
+// OriginalURL builds the URL of the original image of a photo, originalSecret
+// and originalFormat are only returned to users allowed to download it.
+func OriginalURL(server, id, originalSecret, originalFormat string) (string, error) {
+	if server == "" || id == "" || originalSecret == "" || originalFormat == "" {
+		return "", flickErr.NewError(flickErr.ValidationError,
+			"server, id, original secret and original format are required")
+	}
+	return fmt.Sprintf("%s%s/%s_%s_o.%s", STATIC_ENDPOINT, server, id, originalSecret, originalFormat), nil
+}
+
+// PhotoURL builds the URL of any size of a photo, picking the right secret
+// and extension for the original
+func PhotoURL(server, id, secret, originalSecret, originalFormat string, size PhotoSize) (string, error) {
+	if size == SizeOriginal {
+		return OriginalURL(server, id, originalSecret, originalFormat)
+	}
+	return StaticURL(server, id, secret, size)
+}
+
+// BuddyIconURL builds the URL of the buddy icon of a user or group from the
+// iconserver and iconfarm returned along with its nsid. The default icon is
+// returned when no icon was uploaded, that is when iconServer is empty or "0".
+func BuddyIconURL(iconFarm int, iconServer, nsid string) string {
+	if iconServer == "" || iconServer == "0" || nsid == "" {
+		return "https://www.flickr.com/images/buddyicon.gif"
+	}
+	return fmt.Sprintf("https://farm%d.staticflickr.com/%s/buddyicons/%s.jpg", iconFarm, iconServer, nsid)
+}
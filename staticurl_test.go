@@ -0,0 +1,46 @@
+package flickr
+
+import (
+	"testing"
+)
+
+func TestStaticURL(t *testing.T) {
+	url, err := StaticURL("65535", "52840975319", "16de78c2d0", SizeSquare75)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/65535/52840975319_16de78c2d0_s.jpg")
+
+	url, err = StaticURL("65535", "52840975319", "16de78c2d0", SizeMedium500)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/65535/52840975319_16de78c2d0.jpg")
+
+	url, err = StaticURL("65535", "52840975319", "16de78c2d0", SizeLarge1024)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/65535/52840975319_16de78c2d0_b.jpg")
+
+	for _, size := range []PhotoSize{SizeOriginal, SizeLarge1600, SizeXLarge6K} {
+		_, err = StaticURL("65535", "52840975319", "16de78c2d0", size)
+		ExpectValidationError(t, err)
+	}
+	_, err = StaticURL("", "52840975319", "16de78c2d0", SizeSmall240)
+	Expect(t, err != nil, true)
+}
+
+func TestPhotoURL(t *testing.T) {
+	url, err := PhotoURL("65535", "52840975319", "16de78c2d0", "02c20aba2d", "png", SizeOriginal)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/65535/52840975319_02c20aba2d_o.png")
+
+	url, err = PhotoURL("65535", "52840975319", "16de78c2d0", "02c20aba2d", "png", SizeMedium640)
+	Expect(t, err, nil)
+	Expect(t, url, "https://live.staticflickr.com/65535/52840975319_16de78c2d0_z.jpg")
+
+	_, err = PhotoURL("65535", "52840975319", "16de78c2d0", "", "", SizeOriginal)
+	Expect(t, err != nil, true)
+}
+
+func TestBuddyIconURL(t *testing.T) {
+	Expect(t, BuddyIconURL(1, "122", "12037949754@N01"),
+		"https://farm1.staticflickr.com/122/buddyicons/12037949754@N01.jpg")
+	Expect(t, BuddyIconURL(0, "0", "12037949754@N01"), "https://www.flickr.com/images/buddyicon.gif")
+	Expect(t, BuddyIconURL(0, "", "12037949754@N01"), "https://www.flickr.com/images/buddyicon.gif")
+}
@@ -0,0 +1,334 @@
+// Package implementing methods: flickr.stats.*
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+// Stats are computed per day in GMT, Flickr expects days in this format
+const dayLayout = "2006-01-02"
+
+// Views is a views counter
+type Views struct {
+	Views int `xml:"views,attr"`
+}
+
+type TotalViewsResponse struct {
+	flickr.BasicResponse
+	Stats struct {
+		Total        Views `xml:"total"`
+		Photos       Views `xml:"photos"`
+		Photostreams Views `xml:"photostreams"`
+		Sets         Views `xml:"sets"`
+		Collections  Views `xml:"collections"`
+		Galleries    Views `xml:"galleries"`
+	} `xml:"stats"`
+}
+
+// Stats are the counters of a single photo, photoset or collection, comments
+// and favorites are only returned for photos and photosets
+type Stats struct {
+	Views     int `xml:"views,attr"`
+	Comments  int `xml:"comments,attr"`
+	Favorites int `xml:"favorites,attr"`
+}
+
+type StatsResponse struct {
+	flickr.BasicResponse
+	Stats Stats `xml:"stats"`
+}
+
+// Referrer is a page linking to a photo, SearchTerm is set for search engines
+type Referrer struct {
+	URL        string `xml:"url,attr"`
+	SearchTerm string `xml:"searchterm,attr"`
+	Views      int    `xml:"views,attr"`
+}
+
+type ReferrersResponse struct {
+	flickr.BasicResponse
+	Domain struct {
+		Name      string     `xml:"name,attr"`
+		Page      int        `xml:"page,attr"`
+		Pages     int        `xml:"pages,attr"`
+		PerPage   int        `xml:"perpage,attr"`
+		Total     int        `xml:"total,attr"`
+		Referrers []Referrer `xml:"referrer"`
+	} `xml:"domain"`
+}
+
+// PopularPhoto is a photo along with its stats
+type PopularPhoto struct {
+	flickr.Photo
+	Stats Stats
+}
+
+// Replay a start element with no children, so that the photo attributes can
+// be decoded by flickr.Photo
+type emptyElement []xml.Token
+
+func (e *emptyElement) Token() (xml.Token, error) {
+	if len(*e) == 0 {
+		return nil, io.EOF
+	}
+	t := (*e)[0]
+	*e = (*e)[1:]
+	return t, nil
+}
+
+// UnmarshalXML decodes the photo as flickr.Photo and its stats element
+func (p *PopularPhoto) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var children struct {
+		Description string `xml:"description"`
+		Stats       Stats  `xml:"stats"`
+	}
+	if err := d.DecodeElement(&children, &start); err != nil {
+		return err
+	}
+	attrs := xml.NewTokenDecoder(&emptyElement{start, start.End()})
+	if err := attrs.Decode(&p.Photo); err != nil {
+		return err
+	}
+	p.Description = children.Description
+	p.Stats = children.Stats
+	return nil
+}
+
+type PopularPhotosResponse struct {
+	flickr.BasicResponse
+	Photos struct {
+		Page    int            `xml:"page,attr"`
+		Pages   int            `xml:"pages,attr"`
+		PerPage int            `xml:"perpage,attr"`
+		Total   int            `xml:"total,attr"`
+		Photos  []PopularPhoto `xml:"photo"`
+	} `xml:"photos"`
+}
+
+// PopularSort is the counter popular photos are sorted by
+type PopularSort string
+
+const (
+	NoSortSpecified PopularSort = ""
+	SortByViews     PopularSort = "views"
+	SortByComments  PopularSort = "comments"
+	SortByFavorites PopularSort = "favorites"
+)
+
+func requireDate(date time.Time) error {
+	if date.IsZero() {
+		return flickErr.NewError(flickErr.ValidationError, "date is required")
+	}
+	return nil
+}
+
+// Midnight GMT of the day date falls in
+func startOfDay(date time.Time) time.Time {
+	y, m, d := date.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// GetTotalViews returns the views of the calling user's photos, photostream,
+// sets, collections and galleries on the given day, or all time views if date
+// is zero. Days are in GMT, only the calendar day of date is used.
+// This method requires authentication with 'read' permission.
+func GetTotalViews(client *flickr.FlickrClient, date time.Time) (*TotalViewsResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.stats.getTotalViews")
+	if !date.IsZero() {
+		client.Args.Set("date", date.UTC().Format(dayLayout))
+	}
+	client.OAuthSign()
+
+	response := &TotalViewsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetPhotoStats returns the views, comments and favorites of a photo on the
+// given day.
+// This method requires authentication with 'read' permission.
+func GetPhotoStats(client *flickr.FlickrClient, date time.Time, photoId string) (*StatsResponse, error) {
+	if err := requireDate(date); err != nil {
+		return &StatsResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.stats.getPhotoStats")
+	client.Args.Set("date", date.UTC().Format(dayLayout))
+	client.Args.Set("photo_id", photoId)
+	client.OAuthSign()
+
+	response := &StatsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetPhotosetStats returns the views and comments of a photoset on the given day.
+// This method requires authentication with 'read' permission.
+func GetPhotosetStats(client *flickr.FlickrClient, date time.Time, photosetId string) (*StatsResponse, error) {
+	if err := requireDate(date); err != nil {
+		return &StatsResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.stats.getPhotosetStats")
+	client.Args.Set("date", date.UTC().Format(dayLayout))
+	client.Args.Set("photoset_id", photosetId)
+	client.OAuthSign()
+
+	response := &StatsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetCollectionStats returns the views of a collection on the given day.
+// This method requires authentication with 'read' permission.
+func GetCollectionStats(client *flickr.FlickrClient, date time.Time, collectionId string) (*StatsResponse, error) {
+	if err := requireDate(date); err != nil {
+		return &StatsResponse{}, err
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.stats.getCollectionStats")
+	client.Args.Set("date", date.UTC().Format(dayLayout))
+	client.Args.Set("collection_id", collectionId)
+	client.OAuthSign()
+
+	response := &StatsResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetPhotoReferrers returns the pages of domain linking to the calling user's
+// photos on the given day, or to photoId only if not empty. Domains are
+// listed by flickr.stats.getPhotoDomains.
+// This method requires authentication with 'read' permission.
+func GetPhotoReferrers(client *flickr.FlickrClient, date time.Time, domain string, photoId string, perPage, page int) (*ReferrersResponse, error) {
+	if err := requireDate(date); err != nil {
+		return &ReferrersResponse{}, err
+	}
+	if domain == "" {
+		return &ReferrersResponse{}, flickErr.NewError(flickErr.ValidationError, "domain is required")
+	}
+
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.stats.getPhotoReferrers")
+	client.Args.Set("date", date.UTC().Format(dayLayout))
+	client.Args.Set("domain", domain)
+	if photoId != "" {
+		client.Args.Set("photo_id", photoId)
+	}
+	if perPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(perPage))
+	}
+	if page > 0 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+	client.OAuthSign()
+
+	response := &ReferrersResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// GetPopularPhotos returns the calling user's photos with the most views,
+// comments or favorites on the given day, or all time if date is zero.
+// This method requires authentication with 'read' permission.
+func GetPopularPhotos(client *flickr.FlickrClient, date time.Time, sort PopularSort, perPage, page int) (*PopularPhotosResponse, error) {
+	client.Init()
+	client.EndpointUrl = flickr.API_ENDPOINT
+	client.HTTPVerb = "POST"
+	client.Args.Set("method", "flickr.stats.getPopularPhotos")
+	if !date.IsZero() {
+		client.Args.Set("date", date.UTC().Format(dayLayout))
+	}
+	if sort != NoSortSpecified {
+		client.Args.Set("sort", string(sort))
+	}
+	if perPage > 0 {
+		client.Args.Set("per_page", strconv.Itoa(perPage))
+	}
+	if page > 0 {
+		client.Args.Set("page", strconv.Itoa(page))
+	}
+	client.OAuthSign()
+
+	response := &PopularPhotosResponse{}
+	err := flickr.DoPost(client, response)
+	return response, err
+}
+
+// ExportDailyViews writes to w a CSV row with views, comments and favorites
+// for each day from from to to, both included, and each photo in photoIds.
+// Days are in GMT, only the calendar day of from and to is used.
+// If photoIds is empty, the photos are the ones viewed each day as listed by
+// GetPopularPhotos. Rows are preceded by a
+// "date,photo_id,views,comments,favorites" header. On error, the rows fetched
+// so far are written anyway.
+// This method requires authentication with 'read' permission.
+func ExportDailyViews(client *flickr.FlickrClient, w io.Writer, from, to time.Time, photoIds []string) error {
+	from, to = startOfDay(from), startOfDay(to)
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return flickErr.NewError(flickErr.ValidationError, "a valid date range is required")
+	}
+
+	out := csv.NewWriter(w)
+	defer out.Flush()
+	out.Write([]string{"date", "photo_id", "views", "comments", "favorites"})
+	write := func(day time.Time, photoId string, stats Stats) {
+		out.Write([]string{
+			day.Format(dayLayout),
+			photoId,
+			strconv.Itoa(stats.Views),
+			strconv.Itoa(stats.Comments),
+			strconv.Itoa(stats.Favorites),
+		})
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, id := range photoIds {
+			resp, err := GetPhotoStats(client, day, id)
+			if err != nil {
+				return err
+			}
+			write(day, id, resp.Stats)
+		}
+		if len(photoIds) > 0 {
+			continue
+		}
+
+		for page := 1; ; page++ {
+			resp, err := GetPopularPhotos(client, day, SortByViews, 100, page)
+			if err != nil {
+				return err
+			}
+			for _, photo := range resp.Photos.Photos {
+				write(day, photo.Id, photo.Stats)
+			}
+			if page >= resp.Photos.Pages {
+				break
+			}
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,223 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"gopkg.in/masci/flickr.v3"
+	flickErr "gopkg.in/masci/flickr.v3/error"
+)
+
+var day = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestGetTotalViews(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <stats>
+    <total views="469" />
+    <photos views="386" />
+    <photostreams views="72" />
+    <sets views="11" />
+    <collections views="0" />
+    <galleries views="3" />
+  </stats>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetTotalViews(fclient, day)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getTotalViews")
+	flickr.Expect(t, fclient.Args.Get("date"), "2024-03-01")
+	flickr.Expect(t, resp.Stats.Total.Views, 469)
+	flickr.Expect(t, resp.Stats.Photostreams.Views, 72)
+	flickr.Expect(t, resp.Stats.Galleries.Views, 3)
+
+	// days are in GMT whatever the location of date
+	_, err = GetTotalViews(fclient, time.Date(2024, 3, 1, 8, 0, 0, 0, time.FixedZone("AEST", 10*3600)))
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("date"), "2024-02-29")
+
+	// all time views
+	_, err = GetTotalViews(fclient, time.Time{})
+	flickr.Expect(t, err, nil)
+	_, ok := fclient.Args["date"]
+	flickr.Expect(t, ok, false)
+}
+
+func TestGetStats(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="ok"><stats views="24" comments="4" favorites="1" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotoStats(fclient, day, "2733")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getPhotoStats")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2733")
+	flickr.Expect(t, resp.Stats, Stats{Views: 24, Comments: 4, Favorites: 1})
+
+	_, err = GetPhotosetStats(fclient, day, "72157654991267328")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getPhotosetStats")
+	flickr.Expect(t, fclient.Args.Get("photoset_id"), "72157654991267328")
+
+	_, err = GetCollectionStats(fclient, day, "12-72157594586579649")
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getCollectionStats")
+	flickr.Expect(t, fclient.Args.Get("collection_id"), "12-72157594586579649")
+
+	resp, err = GetPhotoStats(fclient, time.Time{}, "2733")
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+	_, err = GetPhotosetStats(fclient, time.Time{}, "72157654991267328")
+	flickr.ExpectValidationError(t, err)
+	_, err = GetCollectionStats(fclient, time.Time{}, "12-72157594586579649")
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestGetPhotoReferrers(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <domain page="1" perpage="25" pages="1" total="2" name="images.search.yahoo.com">
+    <referrer url="http://images.search.yahoo.com/search/images?p=flickr" searchterm="flickr" views="11" />
+    <referrer url="http://images.search.yahoo.com/search/images?p=castle" searchterm="castle" views="2" />
+  </domain>
+</rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPhotoReferrers(fclient, day, "images.search.yahoo.com", "2733", 25, 1)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getPhotoReferrers")
+	flickr.Expect(t, fclient.Args.Get("domain"), "images.search.yahoo.com")
+	flickr.Expect(t, fclient.Args.Get("photo_id"), "2733")
+	flickr.Expect(t, fclient.Args.Get("per_page"), "25")
+	flickr.Expect(t, resp.Domain.Name, "images.search.yahoo.com")
+	flickr.Expect(t, resp.Domain.Total, 2)
+	flickr.Expect(t, resp.Domain.Referrers[0], Referrer{
+		URL: "http://images.search.yahoo.com/search/images?p=flickr", SearchTerm: "flickr", Views: 11})
+
+	resp, err = GetPhotoReferrers(fclient, day, "", "", 0, 0)
+	flickr.ExpectValidationError(t, err)
+	flickr.Expect(t, resp != nil, true)
+	_, err = GetPhotoReferrers(fclient, time.Time{}, "flickr.com", "", 0, 0)
+	flickr.ExpectValidationError(t, err)
+}
+
+const popularPhotos = `<?xml version="1.0" encoding="utf-8" ?>
+<rsp stat="ok">
+  <photos page="1" pages="1" perpage="100" total="2">
+    <photo id="2733" owner="12037949754@N01" secret="123456" server="12" farm="1" title="Orford castle" ispublic="1" isfriend="0" isfamily="0">
+      <stats views="941" comments="18" favorites="2" />
+    </photo>
+    <photo id="2734" owner="12037949754@N01" secret="654321" server="12" farm="1" title="Duomo" ispublic="1" isfriend="0" isfamily="0">
+      <stats views="12" comments="0" favorites="0" />
+    </photo>
+  </photos>
+</rsp>`
+
+func TestGetPopularPhotos(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, popularPhotos, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	resp, err := GetPopularPhotos(fclient, day, SortByComments, 0, 2)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, fclient.Args.Get("method"), "flickr.stats.getPopularPhotos")
+	flickr.Expect(t, fclient.Args.Get("sort"), "comments")
+	flickr.Expect(t, fclient.Args.Get("page"), "2")
+	_, ok := fclient.Args["per_page"]
+	flickr.Expect(t, ok, false)
+	flickr.Expect(t, resp.Photos.Total, 2)
+	photo := resp.Photos.Photos[0]
+	flickr.Expect(t, photo.Id, "2733")
+	flickr.Expect(t, photo.Title, "Orford castle")
+	flickr.Expect(t, photo.Farm, 1)
+	flickr.Expect(t, photo.IsPublic, true)
+	flickr.Expect(t, photo.Stats, Stats{Views: 941, Comments: 18, Favorites: 2})
+	flickr.Expect(t, resp.Photos.Photos[1].Stats.Views, 12)
+}
+
+func TestExportDailyViews(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client, called := flickr.FlickrMethodsMock(map[string]string{
+		"flickr.stats.getPhotoStats":    `<rsp stat="ok"><stats views="24" comments="4" favorites="1" /></rsp>`,
+		"flickr.stats.getPopularPhotos": popularPhotos,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	out := &bytes.Buffer{}
+	err := ExportDailyViews(fclient, out, day, day.AddDate(0, 0, 1), []string{"2733", "2734"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, len(*called), 4)
+	flickr.Expect(t, out.String(), "date,photo_id,views,comments,favorites\n"+
+		"2024-03-01,2733,24,4,1\n"+
+		"2024-03-01,2734,24,4,1\n"+
+		"2024-03-02,2733,24,4,1\n"+
+		"2024-03-02,2734,24,4,1\n")
+
+	// viewed photos are listed when no photo is given
+	out.Reset()
+	err = ExportDailyViews(fclient, out, day, day, nil)
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, (*called)[4], "flickr.stats.getPopularPhotos")
+	flickr.Expect(t, out.String(), "date,photo_id,views,comments,favorites\n"+
+		"2024-03-01,2733,941,18,2\n"+
+		"2024-03-01,2734,12,0,0\n")
+
+	// both ends are included whatever the time of day
+	out.Reset()
+	err = ExportDailyViews(fclient, out, day.Add(15*time.Hour), day.AddDate(0, 0, 2).Add(10*time.Hour), []string{"2733"})
+	flickr.Expect(t, err, nil)
+	flickr.Expect(t, out.String(), "date,photo_id,views,comments,favorites\n"+
+		"2024-03-01,2733,24,4,1\n"+
+		"2024-03-02,2733,24,4,1\n"+
+		"2024-03-03,2733,24,4,1\n")
+	err = ExportDailyViews(fclient, out, day.Add(15*time.Hour), day.Add(10*time.Hour), []string{"2733"})
+	flickr.Expect(t, err, nil)
+
+	err = ExportDailyViews(fclient, out, day, day.AddDate(0, 0, -1), nil)
+	flickr.ExpectValidationError(t, err)
+}
+
+func TestExportDailyViewsError(t *testing.T) {
+	fclient := flickr.GetTestClient()
+	server, client := flickr.FlickrMock(200, `<rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`, "")
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	err := ExportDailyViews(fclient, &bytes.Buffer{}, day, day, []string{"2733"})
+	ee, ok := err.(*flickErr.Error)
+	flickr.Expect(t, ok, true)
+	flickr.Expect(t, ee.ErrorCode, flickErr.ApiError)
+
+	// rows fetched before the error are written
+	calls := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls > 1 {
+			fmt.Fprintln(w, `<rsp stat="fail"><err code="1" msg="Photo not found" /></rsp>`)
+			return
+		}
+		fmt.Fprintln(w, `<rsp stat="ok"><stats views="24" comments="4" favorites="1" /></rsp>`)
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	fclient.HTTPClient = &http.Client{Transport: flickr.RewriteTransport{URL: u}}
+
+	out := &bytes.Buffer{}
+	err = ExportDailyViews(fclient, out, day, day, []string{"2733", "2734"})
+	flickr.Expect(t, err != nil, true)
+	flickr.Expect(t, out.String(), "date,photo_id,views,comments,favorites\n"+
+		"2024-03-01,2733,24,4,1\n")
+}
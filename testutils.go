@@ -11,6 +11,8 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
 )
 
 func Expect(t *testing.T, a interface{}, b interface{}) {
@@ -19,6 +21,15 @@ func Expect(t *testing.T, a interface{}, b interface{}) {
 	}
 }
 
+// Check err is a flickr error raised by client side validation
+func ExpectValidationError(t *testing.T, err error) {
+	ee, ok := err.(*flickErr.Error)
+	Expect(t, ok, true)
+	if ok {
+		Expect(t, ee.ErrorCode, flickErr.ValidationError)
+	}
+}
+
 // testing keys were published at http://www.wackylabs.net/2011/12/oauth-and-flickr-part-2/
 func GetTestClient() *FlickrClient {
 	args := url.Values{}
@@ -71,6 +82,27 @@ func FlickrMock(code int, body string, contentType string) (*httptest.Server, *h
 	return server, &http.Client{Transport: RewriteTransport{URL: u}}
 }
 
+// Mock the Flickr API answering with a different body for each method, calls
+// to methods not listed in bodies fail. Called methods are recorded in order.
+func FlickrMethodsMock(bodies map[string]string) (*httptest.Server, *http.Client, *[]string) {
+	called := &[]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// method is either in the query string or in the multipart body
+		r.ParseMultipartForm(1 << 20)
+		method := r.FormValue("method")
+		*called = append(*called, method)
+		body, ok := bodies[method]
+		if !ok {
+			body = `<rsp stat="fail"><err code="1" msg="Method not mocked" /></rsp>`
+		}
+		fmt.Fprintln(w, body)
+	}))
+
+	u, _ := url.Parse(server.URL)
+
+	return server, &http.Client{Transport: RewriteTransport{URL: u}}, called
+}
+
 // A ReaderCloser to fake http.Response Body field
 type FakeBody struct {
 	content *bytes.Buffer
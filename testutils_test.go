@@ -11,3 +11,21 @@ func TestExpect(t *testing.T) {
 		t.Errorf("Expect should fail")
 	}
 }
+
+func TestFlickrMethodsMock(t *testing.T) {
+	fclient := GetTestClient()
+	server, client, called := FlickrMethodsMock(map[string]string{
+		"flickr.test.null": `<rsp stat="ok"></rsp>`,
+	})
+	defer server.Close()
+	fclient.HTTPClient = client
+
+	fclient.Args.Set("method", "flickr.test.null")
+	Expect(t, DoGet(fclient, &BasicResponse{}), nil)
+	fclient.Args.Set("method", "flickr.test.echo")
+	Expect(t, DoPost(fclient, &BasicResponse{}) != nil, true)
+
+	Expect(t, len(*called), 2)
+	Expect(t, (*called)[0], "flickr.test.null")
+	Expect(t, (*called)[1], "flickr.test.echo")
+}
@@ -1,17 +1,21 @@
 package flickr
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/tls"
 	"fmt"
 	"io"
-	"log"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	flickErr "gopkg.in/masci/flickr.v3/error"
 )
 
 // generate a random multipart boundary string,
@@ -26,24 +30,24 @@ func randomBoundary() string {
 }
 
 // Encode the file and request parameters in a multipart body.
-// File contents are streamed into the request using an io.Pipe in a separated goroutine
+// File contents are streamed into the request using an io.Pipe in a separated goroutine,
+// any error is forwarded to the reading side of the pipe.
 func streamUploadBody(client *FlickrClient, photo io.Reader, body *io.PipeWriter, fileName string, boundary string) {
 	// multipart writer to fill the body
-	defer body.Close()
 	writer := multipart.NewWriter(body)
 	writer.SetBoundary(boundary)
 
 	// create the "photo" field
 	part, err := writer.CreateFormFile("photo", filepath.Base(fileName))
 	if err != nil {
-		log.Fatal(err)
+		body.CloseWithError(err)
 		return
 	}
 
 	// fill the photo field
 	_, err = io.Copy(part, photo)
 	if err != nil {
-		log.Fatal(err)
+		body.CloseWithError(err)
 		return
 	}
 
@@ -54,10 +58,7 @@ func streamUploadBody(client *FlickrClient, photo io.Reader, body *io.PipeWriter
 
 	// close the form writer
 	err = writer.Close()
-	if err != nil {
-		log.Fatal(err)
-		return
-	}
+	body.CloseWithError(err)
 }
 
 // UploadParams is a convenience struct wrapping all optional upload parameters
@@ -68,6 +69,28 @@ type UploadParams struct {
 	ContentType                  int
 	Hidden                       int
 	SafetyLevel                  int
+	// The following are not upload parameters, see OrganizePhoto
+	PhotosetIds    []string // add the photo to these photosets
+	PhotosetTitles []string // add the photo to these photosets, creating the missing ones
+	GroupIds       []string // add the photo to these group pools
+	// What to do when the file was already uploaded, see DuplicatePolicy
+	Duplicates DuplicatePolicy
+	// Machine tags in the form namespace:predicate=value, uploaded along with Tags
+	MachineTags []string
+	// The following are set after the upload, see FollowUpCalls
+	LicenseId string       // license ID as in flickr.photos.licenses.getInfo, "" to use the user's default
+	Geo       *GeoLocation // nil to leave the photo without location
+	// Zero to keep the date Flickr extracts from the file. Flickr has no time
+	// zone for taken dates, the wall clock time is used as is.
+	DateTaken time.Time
+}
+
+// GeoLocation holds the coordinates of a photo
+type GeoLocation struct {
+	Latitude  float64 // -90 to 90
+	Longitude float64 // -180 to 180
+	// From WorldLevel to StreetLevel, NoAccuracySpecified to use the Flickr default
+	Accuracy GeoAccuracy
 }
 
 // NewUploadParams provides meaningful default values
@@ -83,6 +106,58 @@ func NewUploadParams() *UploadParams {
 type UploadResponse struct {
 	BasicResponse
 	ID string `xml:"photoid"`
+	// Whether the upload was skipped because the file was already on Flickr,
+	// in that case ID refers to the existing photo
+	Skipped bool `xml:"-"`
+	// Whether an existing copy of the file was replaced instead of uploading a new photo
+	Replaced bool `xml:"-"`
+}
+
+// Machine tags namespaces and predicates only allow letters, digits and underscores
+var machineTagRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*:[a-zA-Z][a-zA-Z0-9_]*=.+$`)
+
+// Validate checks the parameters are within the ranges accepted by Flickr,
+// returning an error describing every invalid field
+func (params *UploadParams) Validate() error {
+	var problems []string
+	if params.ContentType < 0 || params.ContentType > 3 {
+		problems = append(problems, fmt.Sprintf("content type must be between 1 and 3, got %d", params.ContentType))
+	}
+	if params.Hidden < 0 || params.Hidden > 2 {
+		problems = append(problems, fmt.Sprintf("hidden must be 1 or 2, got %d", params.Hidden))
+	}
+	if params.SafetyLevel < 0 || params.SafetyLevel > 3 {
+		problems = append(problems, fmt.Sprintf("safety level must be between 1 and 3, got %d", params.SafetyLevel))
+	}
+	if params.Duplicates < UploadDuplicates || params.Duplicates > ReplaceDuplicates {
+		problems = append(problems, fmt.Sprintf("unknown duplicate policy %d", params.Duplicates))
+	}
+	for _, tag := range params.MachineTags {
+		if !machineTagRe.MatchString(tag) {
+			problems = append(problems, fmt.Sprintf("machine tag %q is not in the form namespace:predicate=value", tag))
+		}
+	}
+	if params.LicenseId != "" {
+		// unknown ids are left to the API, new licenses are added over time
+		id, err := strconv.Atoi(params.LicenseId)
+		if err != nil || id < 0 {
+			problems = append(problems, fmt.Sprintf("invalid license id %q", params.LicenseId))
+		}
+	}
+	if params.Geo != nil {
+		if !ValidCoordinates(params.Geo.Latitude, params.Geo.Longitude) {
+			problems = append(problems, fmt.Sprintf("latitude must be between -90 and 90 and longitude between -180 and 180, got %v,%v",
+				params.Geo.Latitude, params.Geo.Longitude))
+		}
+		if !params.Geo.Accuracy.Valid() {
+			problems = append(problems, fmt.Sprintf("geo accuracy must be between 1 and 16, got %d", params.Geo.Accuracy))
+		}
+	}
+
+	if len(problems) > 0 {
+		return flickErr.NewError(flickErr.ValidationError, strings.Join(problems, "; "))
+	}
+	return nil
 }
 
 // Set client query arguments based on the contents of the UploadParams struct
@@ -95,8 +170,16 @@ func fillArgsWithParams(client *FlickrClient, params *UploadParams) {
 		client.Args.Set("description", params.Description)
 	}
 
-	if len(params.Tags) > 0 {
-		client.Args.Set("tags", strings.Join(params.Tags, " "))
+	tags := append([]string{}, params.Tags...)
+	for _, tag := range params.MachineTags {
+		// values with spaces must be quoted as any other tag
+		if strings.Contains(tag, " ") {
+			tag = `"` + tag + `"`
+		}
+		tags = append(tags, tag)
+	}
+	if len(tags) > 0 {
+		client.Args.Set("tags", strings.Join(tags, " "))
 	}
 
 	var boolString = func(b bool) string {
@@ -127,13 +210,27 @@ func fillArgsWithParams(client *FlickrClient, params *UploadParams) {
 // default preferences.
 // This call must be signed with write permissions
 func UploadFile(client *FlickrClient, path string, optionalParams *UploadParams) (*UploadResponse, error) {
+	return uploadFileContext(context.Background(), client, path, optionalParams, nil)
+}
+
+// uploadFileContext opens the file at path and uploads it, checking for duplicates if requested
+func uploadFileContext(ctx context.Context, client *FlickrClient, path string, optionalParams *UploadParams, httpClient *http.Client) (*UploadResponse, error) {
+	if optionalParams != nil {
+		if err := optionalParams.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	return UploadReader(client, file, file.Name(), optionalParams)
+	if optionalParams != nil && optionalParams.Duplicates != UploadDuplicates {
+		return uploadDeduplicated(ctx, client, file, optionalParams, httpClient)
+	}
+	return uploadReaderContext(ctx, client, file, file.Name(), optionalParams, httpClient)
 }
 
 // UploadReader does same as UploadFile but the photo file is passed as an io.Reader instead of a file path
@@ -143,14 +240,45 @@ func UploadReader(client *FlickrClient, photoReader io.Reader, name string, opti
 
 // UploadReaderWithClient does same as UploadReader but allows passing a custom httpClient
 func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name string, optionalParams *UploadParams, httpClient *http.Client) (*UploadResponse, error) {
+	return uploadReaderContext(context.Background(), client, photoReader, name, optionalParams, httpClient)
+}
+
+// uploadReaderContext performs the actual upload request, aborting it as soon as ctx is done
+func uploadReaderContext(ctx context.Context, client *FlickrClient, photoReader io.Reader, name string, optionalParams *UploadParams, httpClient *http.Client) (*UploadResponse, error) {
 	client.Init()
 	client.EndpointUrl = UPLOAD_ENDPOINT
 	client.HTTPVerb = "POST"
 
 	if optionalParams != nil {
+		if err := optionalParams.Validate(); err != nil {
+			return nil, err
+		}
 		fillArgsWithParams(client, optionalParams)
 	}
 
+	return postFileContext(ctx, client, photoReader, name, httpClient)
+}
+
+// Create the HTTP client used for uploads when none is given
+func newUploadHTTPClient() *http.Client {
+	// Create a Transport to explicitly use the http1.1 client
+	// TODO: for some reason, when we use the http2 client flickr API responds
+	// with HTTP: 411 (No Content Length : POST) whereas it should be ok to
+	// upload using chunks. Explicitly setting `req.Header.Set("transfer-encoding", "chunked")`
+	// does not help and try to compute the request size isn't the right thing to do IMHO.
+	// We should investigate why this happens instead of forcing the downgrade to http1.1.
+	tr := &http.Transport{
+		TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
+	}
+
+	// instance an HTTP client
+	return &http.Client{Transport: tr}
+}
+
+// postFileContext signs the request and streams the file along with client Args
+// to client.EndpointUrl, aborting as soon as ctx is done
+func postFileContext(ctx context.Context, client *FlickrClient, photoReader io.Reader, name string, httpClient *http.Client) (*UploadResponse, error) {
+	client.HTTPVerb = "POST"
 	client.OAuthSign()
 
 	// write request body in a Pipe
@@ -159,7 +287,7 @@ func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name st
 	go streamUploadBody(client, photoReader, w, name, boundary)
 
 	// create an HTTP Request
-	req, err := http.NewRequest("POST", client.EndpointUrl, r)
+	req, err := http.NewRequestWithContext(ctx, "POST", client.EndpointUrl, r)
 	if err != nil {
 		return nil, err
 	}
@@ -169,18 +297,7 @@ func UploadReaderWithClient(client *FlickrClient, photoReader io.Reader, name st
 	req.ContentLength = -1 // unknown
 
 	if (httpClient == nil) {
-		// Create a Transport to explicitly use the http1.1 client
-		// TODO: for some reason, when we use the http2 client flickr API responds
-		// with HTTP: 411 (No Content Length : POST) whereas it should be ok to
-		// upload using chunks. Explicitly setting `req.Header.Set("transfer-encoding", "chunked")`
-		// does not help and try to compute the request size isn't the right thing to do IMHO.
-		// We should investigate why this happens instead of forcing the downgrade to http1.1.
-		tr := &http.Transport{
-			TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
-		}
-
-		// instance an HTTP client
-		httpClient = &http.Client{Transport: tr}
+		httpClient = newUploadHTTPClient()
 	}
 
 	// perform upload request streaming the file
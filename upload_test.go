@@ -3,7 +3,9 @@ package flickr
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	flickErr "gopkg.in/masci/flickr.v3/error"
 )
@@ -84,3 +86,54 @@ func TestUploadFileKo(t *testing.T) {
 	Expect(t, ok, true)
 	Expect(t, resp.HasErrors(), true)
 }
+
+func TestFillArgsWithMachineTags(t *testing.T) {
+	client := GetTestClient()
+	params := NewUploadParams()
+	params.Tags = []string{"foo"}
+	params.MachineTags = []string{"geo:country=italy", "camera:model=EOS 5D"}
+	fillArgsWithParams(client, params)
+	Expect(t, client.Args.Get("tags"), `foo geo:country=italy "camera:model=EOS 5D"`)
+	Expect(t, len(params.Tags), 1)
+}
+
+func TestValidate(t *testing.T) {
+	params := NewUploadParams()
+	Expect(t, params.Validate(), nil)
+	Expect(t, (&UploadParams{}).Validate(), nil)
+
+	params.LicenseId = "42"
+	Expect(t, params.Validate(), nil)
+	params.LicenseId = "0"
+	params.Geo = &GeoLocation{Latitude: -33.87, Longitude: 151.21, Accuracy: 16}
+	params.MachineTags = []string{"checksum:sha256=abc"}
+	params.DateTaken = time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	Expect(t, params.Validate(), nil)
+	// wall clock time east of UTC, stored as UTC
+	params.DateTaken = time.Now().UTC().Add(10 * time.Hour)
+	Expect(t, params.Validate(), nil)
+
+	params.ContentType = 100
+	params.Hidden = -1
+	params.SafetyLevel = 4
+	params.Duplicates = 3
+	params.LicenseId = "cc-by"
+	params.Geo = &GeoLocation{Latitude: 91, Longitude: -181, Accuracy: 17}
+	params.MachineTags = []string{"checksum:sha256=abc", "foo:bar", "1ns:pred=val"}
+	err := params.Validate()
+	ExpectValidationError(t, err)
+	for _, field := range []string{"content type", "hidden", "safety level", "duplicate policy",
+		`"foo:bar"`, `"1ns:pred=val"`, `"cc-by"`, "latitude", "longitude", "accuracy"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("%q not found in %q", field, err.Error())
+		}
+	}
+	Expect(t, strings.Contains(err.Error(), "checksum:sha256=abc"), false)
+
+	// invalid params are not uploaded
+	resp, err := UploadFile(GetTestClient(), "", params)
+	Expect(t, resp == nil, true)
+	ExpectValidationError(t, err)
+	_, err = UploadReader(GetTestClient(), strings.NewReader(""), "", params)
+	ExpectValidationError(t, err)
+}